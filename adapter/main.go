@@ -19,8 +19,8 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
 	prommetrics "github.com/kedacore/keda/pkg/metrics"
+	"github.com/kedacore/keda/pkg/metricsservice"
 	kedaprovider "github.com/kedacore/keda/pkg/provider"
-	"github.com/kedacore/keda/pkg/scaling"
 	"github.com/kedacore/keda/version"
 )
 
@@ -37,6 +37,8 @@ var logger = klogr.New().WithName("keda_metrics_adapter")
 var (
 	prometheusMetricsPort int
 	prometheusMetricsPath string
+	metricsServiceAddress string
+	metricsServiceCertDir string
 )
 
 func (a *Adapter) makeProviderOrDie() provider.MetricsProvider {
@@ -65,7 +67,11 @@ func (a *Adapter) makeProviderOrDie() provider.MetricsProvider {
 		os.Exit(1)
 	}
 
-	handler := scaling.NewScaleHandler(kubeclient, nil, scheme)
+	grpcClient, err := metricsservice.NewGrpcClient(metricsServiceAddress, metricsServiceCertDir)
+	if err != nil {
+		logger.Error(err, "unable to connect to KEDA Operator Metrics Service")
+		os.Exit(1)
+	}
 
 	namespace, err := getWatchNamespace()
 	if err != nil {
@@ -76,7 +82,13 @@ func (a *Adapter) makeProviderOrDie() provider.MetricsProvider {
 	prometheusServer := &prommetrics.PrometheusMetricServer{}
 	go func() { prometheusServer.NewServer(fmt.Sprintf(":%v", prometheusMetricsPort), prometheusMetricsPath) }()
 
-	return kedaprovider.NewProvider(logger, handler, kubeclient, namespace)
+	mapper, err := a.RESTMapper()
+	if err != nil {
+		logger.Error(err, "unable to construct discovery REST mapper")
+		os.Exit(1)
+	}
+
+	return kedaprovider.NewProvider(logger, grpcClient, kubeclient, mapper, namespace)
 }
 
 func printVersion() {
@@ -106,10 +118,13 @@ func main() {
 	cmd.Flags().AddGoFlagSet(flag.CommandLine) // make sure we get the klog flags
 	cmd.Flags().IntVar(&prometheusMetricsPort, "metrics-port", 9022, "Set the port to expose prometheus metrics")
 	cmd.Flags().StringVar(&prometheusMetricsPath, "metrics-path", "/metrics", "Set the path for the prometheus metrics endpoint")
+	cmd.Flags().StringVar(&metricsServiceAddress, "metrics-service-address", "keda-operator.keda.svc.cluster.local:9666", "The address of the KEDA Operator Metrics Service (gRPC) the metrics are requested from")
+	cmd.Flags().StringVar(&metricsServiceCertDir, "metrics-service-cert-dir", "/certs", "The directory with the client certificate (tls.crt and tls.key) for the KEDA Operator Metrics Service and the CA (ca.crt) its certificate is verified with")
 	cmd.Flags().Parse(os.Args)
 
 	kedaProvider := cmd.makeProviderOrDie()
 	cmd.WithExternalMetrics(kedaProvider)
+	cmd.WithCustomMetrics(kedaProvider)
 
 	logger.Info(cmd.Message)
 	if err := cmd.Run(wait.NeverStop); err != nil {
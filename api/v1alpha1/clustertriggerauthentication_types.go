@@ -0,0 +1,49 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTriggerAuthentication defines how a trigger can authenticate globally,
+// secrets referenced by the ClusterTriggerAuthentication are read from the namespace of KEDA
+// +genclient
+// +genclient:nonNamespaced
+// +kubebuilder:resource:path=clustertriggerauthentications,scope=Cluster,shortName=cta;clustertriggerauth
+// +kubebuilder:printcolumn:name="PodIdentity",type="string",JSONPath=".spec.podIdentity.provider"
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretTargetRef[*].name"
+// +kubebuilder:printcolumn:name="Env",type="string",JSONPath=".spec.env[*].name"
+type ClusterTriggerAuthentication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterTriggerAuthenticationSpec `json:"spec"`
+}
+
+// ClusterTriggerAuthenticationSpec defines the various ways to authenticate
+// and the namespaces that are allowed to use the ClusterTriggerAuthentication
+type ClusterTriggerAuthenticationSpec struct {
+	TriggerAuthenticationSpec `json:",inline"`
+
+	// AllowedNamespaces restricts the usage of the ClusterTriggerAuthentication to the listed namespaces
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// NamespaceSelector restricts the usage of the ClusterTriggerAuthentication to namespaces matching the selector
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTriggerAuthenticationList contains a list of ClusterTriggerAuthentication
+type ClusterTriggerAuthenticationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ClusterTriggerAuthentication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterTriggerAuthentication{}, &ClusterTriggerAuthenticationList{})
+}
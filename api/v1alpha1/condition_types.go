@@ -14,6 +14,10 @@ const (
 	// ConditionActive specifies that the resource has finished.
 	// For resource which run to completion.
 	ConditionActive ConditionType = "Active"
+	// ConditionFallback specifies that the resource has a fallback active.
+	ConditionFallback ConditionType = "Fallback"
+	// ConditionPaused specifies that the resource is paused.
+	ConditionPaused ConditionType = "Paused"
 )
 
 // Condition to store the condition state
@@ -42,27 +46,28 @@ type Conditions []Condition
 // return true if Conditions are initialized
 // return false if Conditions are not initialized
 func (c *Conditions) AreInitialized() bool {
-	foundReady := false
-	foundActive := false
-	if *c != nil {
+	if *c == nil {
+		return false
+	}
+
+	for _, initialized := range *GetInitializedConditions() {
+		found := false
 		for _, condition := range *c {
-			if condition.Type == ConditionReady {
-				foundReady = true
+			if condition.Type == initialized.Type {
+				found = true
 				break
 			}
 		}
-		for _, condition := range *c {
-			if condition.Type == ConditionActive {
-				foundActive = true
-				break
-			}
+		if !found {
+			return false
 		}
 	}
-
-	return foundReady && foundActive
+	return true
 }
 
 // GetInitializedConditions returns Conditions initialized to the default -> Status: Unknown
+// Fallback and Paused are not part of them, they are only added to ScaledObjects when a fallback happens
+// or when the paused-replicas annotation is set
 func GetInitializedConditions() *Conditions {
 	return &Conditions{{Type: ConditionReady, Status: metav1.ConditionUnknown}, {Type: ConditionActive, Status: metav1.ConditionUnknown}}
 }
@@ -107,6 +112,16 @@ func (c *Conditions) SetActiveCondition(status metav1.ConditionStatus, reason st
 	c.setCondition(ConditionActive, status, reason, message)
 }
 
+// SetFallbackCondition modifies Fallback Condition according to input parameters, it is added if missing
+func (c *Conditions) SetFallbackCondition(status metav1.ConditionStatus, reason string, message string) {
+	c.setCondition(ConditionFallback, status, reason, message)
+}
+
+// SetPausedCondition modifies Paused Condition according to input parameters, it is added if missing
+func (c *Conditions) SetPausedCondition(status metav1.ConditionStatus, reason string, message string) {
+	c.setCondition(ConditionPaused, status, reason, message)
+}
+
 // GetActiveCondition returns Condition of type Active
 func (c *Conditions) GetActiveCondition() Condition {
 	if *c == nil {
@@ -115,6 +130,22 @@ func (c *Conditions) GetActiveCondition() Condition {
 	return c.getCondition(ConditionActive)
 }
 
+// GetFallbackCondition returns Condition of type Fallback, its status is Unknown if it was never set
+func (c *Conditions) GetFallbackCondition() Condition {
+	if condition := c.getCondition(ConditionFallback); condition.Type != "" {
+		return condition
+	}
+	return Condition{Type: ConditionFallback, Status: metav1.ConditionUnknown}
+}
+
+// GetPausedCondition returns Condition of type Paused, its status is Unknown if it was never set
+func (c *Conditions) GetPausedCondition() Condition {
+	if condition := c.getCondition(ConditionPaused); condition.Type != "" {
+		return condition
+	}
+	return Condition{Type: ConditionPaused, Status: metav1.ConditionUnknown}
+}
+
 func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	for i := range c {
 		if c[i].Type == conditionType {
@@ -124,13 +155,14 @@ func (c Conditions) getCondition(conditionType ConditionType) Condition {
 	return Condition{}
 }
 
-func (c Conditions) setCondition(conditionType ConditionType, status metav1.ConditionStatus, reason string, message string) {
-	for i := range c {
-		if c[i].Type == conditionType {
-			c[i].Status = status
-			c[i].Reason = reason
-			c[i].Message = message
-			break
+func (c *Conditions) setCondition(conditionType ConditionType, status metav1.ConditionStatus, reason string, message string) {
+	for i := range *c {
+		if (*c)[i].Type == conditionType {
+			(*c)[i].Status = status
+			(*c)[i].Reason = reason
+			(*c)[i].Message = message
+			return
 		}
 	}
+	*c = append(*c, Condition{Type: conditionType, Status: status, Reason: reason, Message: message})
 }
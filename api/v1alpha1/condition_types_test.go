@@ -0,0 +1,44 @@
+package v1alpha1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFallbackConditionIsNotInitialized(t *testing.T) {
+	conditions := GetInitializedConditions()
+	if !conditions.AreInitialized() {
+		t.Fatal("Expected initialized conditions")
+	}
+	initialized := len(*conditions)
+
+	if condition := conditions.GetFallbackCondition(); !condition.IsUnknown() || condition.Type != ConditionFallback {
+		t.Errorf("Expected unknown Fallback condition but got %v", condition)
+	}
+
+	conditions.SetFallbackCondition(metav1.ConditionTrue, "FallbackExists", "fallback")
+	if condition := conditions.GetFallbackCondition(); !condition.IsTrue() {
+		t.Errorf("Expected Fallback condition to be added")
+	}
+	conditions.SetFallbackCondition(metav1.ConditionFalse, "NoFallbackFound", "no fallback")
+	if condition := conditions.GetFallbackCondition(); len(*conditions) != initialized+1 || !condition.IsFalse() {
+		t.Errorf("Expected Fallback condition to be updated but got %v", *conditions)
+	}
+}
+
+func TestPausedConditionIsNotInitialized(t *testing.T) {
+	conditions := GetInitializedConditions()
+	if len(*conditions) != 2 {
+		t.Errorf("Expected only Ready and Active conditions but got %v", *conditions)
+	}
+
+	if condition := conditions.GetPausedCondition(); !condition.IsUnknown() || condition.Type != ConditionPaused {
+		t.Errorf("Expected unknown Paused condition but got %v", condition)
+	}
+
+	conditions.SetPausedCondition(metav1.ConditionTrue, "ScaledObjectPaused", "paused")
+	if condition := conditions.GetPausedCondition(); len(*conditions) != 3 || !condition.IsTrue() {
+		t.Errorf("Expected Paused condition to be added but got %v", *conditions)
+	}
+}
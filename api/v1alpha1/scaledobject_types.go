@@ -15,6 +15,8 @@ import (
 // +kubebuilder:printcolumn:name="Authentication",type="string",JSONPath=".spec.triggers[*].authenticationRef.name"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 // +kubebuilder:printcolumn:name="Active",type="string",JSONPath=".status.conditions[?(@.type==\"Active\")].status"
+// +kubebuilder:printcolumn:name="Fallback",type="string",JSONPath=".status.conditions[?(@.type==\"Fallback\")].status"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=".status.conditions[?(@.type==\"Paused\")].status"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ScaledObject is a specification for a ScaledObject resource
@@ -27,6 +29,10 @@ type ScaledObject struct {
 	Status ScaledObjectStatus `json:"status,omitempty"`
 }
 
+// PausedReplicasAnnotation is the annotation of a ScaledObject which pauses autoscaling,
+// the scale target is kept at the replica count specified by the annotation
+const PausedReplicasAnnotation = "autoscaling.keda.sh/paused-replicas"
+
 // ScaledObjectSpec is the spec for a ScaledObject resource
 type ScaledObjectSpec struct {
 	ScaleTargetRef *ScaleTarget `json:"scaleTargetRef"`
@@ -42,6 +48,17 @@ type ScaledObjectSpec struct {
 	Advanced *AdvancedConfig `json:"advanced,omitempty"`
 
 	Triggers []ScaleTriggers `json:"triggers"`
+	// +optional
+	Fallback *Fallback `json:"fallback,omitempty"`
+}
+
+// Fallback is the spec for fallback options, the scale target is kept at Replicas
+// once a trigger fails FailureThreshold times in a row
+type Fallback struct {
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold int32 `json:"failureThreshold"`
+	// +kubebuilder:validation:Minimum=0
+	Replicas int32 `json:"replicas"`
 }
 
 // AdvancedConfig specifies advance scaling options
@@ -50,6 +67,19 @@ type AdvancedConfig struct {
 	HorizontalPodAutoscalerConfig *HorizontalPodAutoscalerConfig `json:"horizontalPodAutoscalerConfig,omitempty"`
 	// +optional
 	RestoreToOriginalReplicaCount bool `json:"restoreToOriginalReplicaCount,omitempty"`
+	// +optional
+	ScalingModifiers *ScalingModifiers `json:"scalingModifiers,omitempty"`
+}
+
+// ScalingModifiers describes a composite metric computed by a formula from metrics of named triggers,
+// the composite metric replaces the triggers' external metrics in the HPA
+type ScalingModifiers struct {
+	Formula string `json:"formula"`
+	Target  string `json:"target"`
+	// +optional
+	ActivationTarget string `json:"activationTarget,omitempty"`
+	// +optional
+	MetricType autoscalingv2beta2.MetricTargetType `json:"metricType,omitempty"`
 }
 
 // HorizontalPodAutoscalerConfig specifies horizontal scale config
@@ -96,8 +126,29 @@ type ScaledObjectStatus struct {
 	ResourceMetricNames []string `json:"resourceMetricNames,omitempty"`
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
+	// +optional
+	Health map[string]HealthStatus `json:"health,omitempty"`
 }
 
+// HealthStatus is the status for a ScaledObject's metric
+type HealthStatus struct {
+	// +optional
+	NumberOfFailures *int32 `json:"numberOfFailures,omitempty"`
+	// +optional
+	Status HealthStatusType `json:"status,omitempty"`
+}
+
+// HealthStatusType is an indication of whether the health status is happy or failing
+type HealthStatusType string
+
+const (
+	// HealthStatusHappy means the status of the health object is happy
+	HealthStatusHappy HealthStatusType = "Happy"
+
+	// HealthStatusFailing means the status of the health object is failing
+	HealthStatusFailing HealthStatusType = "Failing"
+)
+
 // +kubebuilder:object:root=true
 
 // ScaledObjectList is a list of ScaledObject resources
@@ -107,10 +158,14 @@ type ScaledObjectList struct {
 	Items           []ScaledObject `json:"items"`
 }
 
-// ScaledObjectAuthRef points to the TriggerAuthentication object that
+// ScaledObjectAuthRef points to the TriggerAuthentication or ClusterTriggerAuthentication object that
 // is used to authenticate the scaler with the environment
 type ScaledObjectAuthRef struct {
 	Name string `json:"name"`
+	// Kind of the resource being referred to. Defaults to TriggerAuthentication.
+	// +kubebuilder:validation:Enum=TriggerAuthentication;ClusterTriggerAuthentication
+	// +optional
+	Kind string `json:"kind,omitempty"`
 }
 
 func init() {
@@ -55,6 +55,8 @@ const (
 	PodIdentityProviderSpiffe  PodIdentityProvider = "spiffe"
 	PodIdentityProviderAwsEKS  PodIdentityProvider = "aws-eks"
 	PodIdentityProviderAwsKiam PodIdentityProvider = "aws-kiam"
+	// PodIdentityProviderAwsWebIdentity assumes a role with the projected service account token of the KEDA operator
+	PodIdentityProviderAwsWebIdentity PodIdentityProvider = "aws-web-identity"
 )
 
 // PodIdentityAnnotationEKS specifies aws role arn for aws-eks Identity Provider
@@ -13,6 +13,7 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/controllers/util"
+	"github.com/kedacore/keda/pkg/scaling/modifiers"
 )
 
 const (
@@ -137,28 +138,46 @@ func (r *ScaledObjectReconciler) getScaledObjectMetricSpecs(logger logr.Logger,
 	var externalMetricNames []string
 	var resourceMetricNames []string
 
-	scalers, err := r.scaleHandler.GetScalers(scaledObject)
+	scalersCache, err := r.ScaleHandler.GetScalersCache(scaledObject)
 	if err != nil {
 		logger.Error(err, "Error getting scalers")
 		return nil, err
 	}
+	defer scalersCache.Release()
 
-	for _, scaler := range scalers {
+	scalingModifiers := modifiers.GetScalingModifiers(scaledObject)
+
+	for _, scaler := range scalersCache.GetScalers() {
 		metricSpecs := scaler.GetMetricSpecForScaling()
 
 		for _, metricSpec := range metricSpecs {
 			if metricSpec.Resource != nil {
 				resourceMetricNames = append(resourceMetricNames, string(metricSpec.Resource.Name))
+				if scalingModifiers != nil {
+					scaledObjectMetricSpecs = append(scaledObjectMetricSpecs, metricSpec)
+				}
 			}
-			if metricSpec.External != nil {
+			if metricSpec.External != nil && scalingModifiers == nil {
 				// add the scaledObjectName label. This is how the MetricsAdapter will know which scaledobject a metric is for when the HPA queries it.
 				metricSpec.External.Metric.Selector = &metav1.LabelSelector{MatchLabels: make(map[string]string)}
 				metricSpec.External.Metric.Selector.MatchLabels["scaledObjectName"] = scaledObject.Name
 				externalMetricNames = append(externalMetricNames, metricSpec.External.Metric.Name)
 			}
 		}
-		scaledObjectMetricSpecs = append(scaledObjectMetricSpecs, metricSpecs...)
-		scaler.Close()
+		if scalingModifiers == nil {
+			scaledObjectMetricSpecs = append(scaledObjectMetricSpecs, metricSpecs...)
+		}
+	}
+
+	// triggers' external metrics are replaced by a single metric computed from the formula
+	if scalingModifiers != nil {
+		compositeMetricSpec, err := modifiers.GetCompositeMetricSpec(scaledObject)
+		if err != nil {
+			logger.Error(err, "Error building composite metric from scalingModifiers")
+			return nil, err
+		}
+		scaledObjectMetricSpecs = append(scaledObjectMetricSpecs, compositeMetricSpec)
+		externalMetricNames = []string{modifiers.CompositeMetricName}
 	}
 
 	// store External.MetricNames,Resource.MetricsNames used by scalers defined in the ScaledObject
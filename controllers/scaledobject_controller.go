@@ -14,39 +14,40 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
-	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/cache"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
 	kedacontrollerutil "github.com/kedacore/keda/controllers/util"
 	"github.com/kedacore/keda/pkg/scaling"
+	"github.com/kedacore/keda/pkg/scaling/modifiers"
 	kedautil "github.com/kedacore/keda/pkg/util"
 )
 
 // +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects;scaledobjects/finalizers;scaledobjects/status,verbs="*"
 // +kubebuilder:rbac:groups=keda.sh,resources=triggerauthentications;triggerauthentications/status,verbs="*"
+// +kubebuilder:rbac:groups=keda.sh,resources=clustertriggerauthentications;clustertriggerauthentications/status,verbs="*"
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs="*"
 // +kubebuilder:rbac:groups="",resources=configmaps;configmaps/status;events,verbs="*"
 // +kubebuilder:rbac:groups="",resources=pods;services;services;secrets;external,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="*",resources="*/scale",verbs="*"
 // +kubebuilder:rbac:groups="*",resources="*",verbs=get
 
 // ScaledObjectReconciler reconciles a ScaledObject object
 type ScaledObjectReconciler struct {
-	Log    logr.Logger
-	Client client.Client
-	Scheme *runtime.Scheme
+	Log          logr.Logger
+	Client       client.Client
+	Scheme       *runtime.Scheme
+	ScaleClient  *scale.ScalesGetter
+	ScaleHandler scaling.ScaleHandler
 
-	scaleClient              *scale.ScalesGetter
 	restMapper               meta.RESTMapper
 	scaledObjectsGenerations *sync.Map
-	scaleHandler             scaling.ScaleHandler
 	kubeVersion              kedautil.K8sVersion
 }
 
@@ -68,34 +69,21 @@ func (r *ScaledObjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		r.Log.Error(err, "Not able to get Kubernetes version")
 	}
 
-	// Create Scale Client
-	scaleClient := initScaleClient(mgr, clientset)
-	r.scaleClient = &scaleClient
-
 	// Init the rest of ScaledObjectReconciler
 	r.restMapper = mgr.GetRESTMapper()
 	r.scaledObjectsGenerations = &sync.Map{}
-	r.scaleHandler = scaling.NewScaleHandler(mgr.GetClient(), r.scaleClient, mgr.GetScheme())
 
 	// Start controller
 	return ctrl.NewControllerManagedBy(mgr).
 		// predicate.GenerationChangedPredicate{} ignore updates to ScaledObject Status
 		// (in this case metadata.Generation does not change)
-		// so reconcile loop is not started on Status updates
-		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// so reconcile loop is not started on Status updates,
+		// pausedReplicasPredicate{} starts reconcile loop when autoscaling is paused or resumed
+		For(&kedav1alpha1.ScaledObject{}, builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, pausedReplicasPredicate{}))).
 		Owns(&autoscalingv2beta2.HorizontalPodAutoscaler{}).
 		Complete(r)
 }
 
-func initScaleClient(mgr manager.Manager, clientset *discovery.DiscoveryClient) scale.ScalesGetter {
-	scaleKindResolver := scale.NewDiscoveryScaleKindResolver(clientset)
-	return scale.New(
-		clientset.RESTClient(), mgr.GetRESTMapper(),
-		dynamic.LegacyAPIPathResolverFunc,
-		scaleKindResolver,
-	)
-}
-
 // Reconcile performs reconciliation on the identified ScaledObject resource based on the request information passed, returns the result and an error (if any).
 func (r *ScaledObjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	reqLogger := r.Log.WithValues("ScaledObject.Namespace", req.Namespace, "ScaledObject.Name", req.Name)
@@ -144,6 +132,12 @@ func (r *ScaledObjectReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error
 	} else {
 		reqLogger.V(1).Info(msg)
 		conditions.SetReadyCondition(metav1.ConditionTrue, "ScaledObjectReady", msg)
+		if pausedReplicas, _ := getPausedReplicaCount(scaledObject); pausedReplicas != nil {
+			conditions.SetPausedCondition(metav1.ConditionTrue, "ScaledObjectPaused", fmt.Sprintf("Autoscaling is paused at %d replicas", *pausedReplicas))
+		} else if pausedCondition := conditions.GetPausedCondition(); pausedCondition.IsTrue() {
+			// the Paused condition is only kept on ScaledObjects which have been paused
+			conditions.SetPausedCondition(metav1.ConditionFalse, "ScaledObjectNotPaused", "Autoscaling is not paused")
+		}
 	}
 	kedacontrollerutil.SetStatusConditions(r.Client, reqLogger, scaledObject, &conditions)
 	return ctrl.Result{}, err
@@ -169,6 +163,23 @@ func (r *ScaledObjectReconciler) reconcileScaledObject(logger logr.Logger, scale
 		return "ScaledObject doesn't have correct scaleTargetRef specification", err
 	}
 
+	// Pause autoscaling if the paused-replicas annotation is specified
+	pausedReplicas, err := getPausedReplicaCount(scaledObject)
+	if err != nil {
+		return "ScaledObject doesn't have correct paused-replicas annotation", err
+	}
+	if pausedReplicas != nil {
+		if err := r.pauseScaledObject(logger, scaledObject, &gvkr, *pausedReplicas); err != nil {
+			return "Failed to pause autoscaling of ScaledObject", err
+		}
+		return "ScaledObject is paused", nil
+	}
+
+	// Check the formula and targets of scalingModifiers, if specified
+	if err := modifiers.Validate(scaledObject); err != nil {
+		return "ScaledObject doesn't have correct scalingModifiers specification", err
+	}
+
 	// Create a new HPA or update existing one according to ScaledObject
 	newHPACreated, err := r.ensureHPAForScaledObjectExists(logger, scaledObject, &gvkr)
 	if err != nil {
@@ -225,7 +236,7 @@ func (r *ScaledObjectReconciler) checkTargetResourceIsScalable(logger logr.Logge
 	logger.V(1).Info("Parsed Group, Version, Kind, Resource", "GVK", gvkString, "Resource", gvkr.Resource)
 
 	// let's try to detect /scale subresource
-	scale, errScale := (*r.scaleClient).Scales(scaledObject.Namespace).Get(context.TODO(), gvkr.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+	scale, errScale := (*r.ScaleClient).Scales(scaledObject.Namespace).Get(context.TODO(), gvkr.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
 	if errScale != nil {
 		// not able to get /scale subresource -> let's check if the resource even exist in the cluster
 		unstruct := &unstructured.Unstructured{}
@@ -305,7 +316,7 @@ func (r *ScaledObjectReconciler) requestScaleLoop(logger logr.Logger, scaledObje
 		return err
 	}
 
-	if err = r.scaleHandler.HandleScalableObject(scaledObject); err != nil {
+	if err = r.ScaleHandler.HandleScalableObject(scaledObject); err != nil {
 		return err
 	}
 
@@ -323,7 +334,7 @@ func (r *ScaledObjectReconciler) stopScaleLoop(logger logr.Logger, scaledObject
 		return err
 	}
 
-	if err := r.scaleHandler.DeleteScalableObject(scaledObject); err != nil {
+	if err := r.ScaleHandler.DeleteScalableObject(scaledObject); err != nil {
 		return err
 	}
 	// delete ScaledObject's current Generation
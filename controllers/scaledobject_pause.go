@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+// pausedReplicasPredicate triggers reconcile when the paused-replicas annotation of the ScaledObject is added, changed or removed,
+// changes of annotations don't change metadata.Generation
+type pausedReplicasPredicate struct {
+	predicate.Funcs
+}
+
+// Update returns true if the paused-replicas annotation was changed
+func (pausedReplicasPredicate) Update(e event.UpdateEvent) bool {
+	if e.MetaOld == nil || e.MetaNew == nil {
+		return false
+	}
+	oldValue, oldFound := e.MetaOld.GetAnnotations()[kedav1alpha1.PausedReplicasAnnotation]
+	newValue, newFound := e.MetaNew.GetAnnotations()[kedav1alpha1.PausedReplicasAnnotation]
+	return oldFound != newFound || oldValue != newValue
+}
+
+// getPausedReplicaCount returns the replica count specified by the paused-replicas annotation or nil if the ScaledObject is not paused
+func getPausedReplicaCount(scaledObject *kedav1alpha1.ScaledObject) (*int32, error) {
+	value, found := scaledObject.GetAnnotations()[kedav1alpha1.PausedReplicasAnnotation]
+	if !found {
+		return nil, nil
+	}
+
+	replicas, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s annotation: %s", kedav1alpha1.PausedReplicasAnnotation, err)
+	}
+	if replicas < 0 {
+		return nil, fmt.Errorf("%s annotation must not be negative", kedav1alpha1.PausedReplicasAnnotation)
+	}
+
+	pausedReplicas := int32(replicas)
+	return &pausedReplicas, nil
+}
+
+// pauseScaledObject stops the ScaleLoop, deletes the HPA and scales the scale target to the paused replica count
+func (r *ScaledObjectReconciler) pauseScaledObject(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, gvkr *kedav1alpha1.GroupVersionKindResource, pausedReplicas int32) error {
+	if err := r.stopScaleLoop(logger, scaledObject); err != nil {
+		return err
+	}
+
+	if err := r.deleteHPA(logger, scaledObject); err != nil {
+		return err
+	}
+
+	scale, err := (*r.ScaleClient).Scales(scaledObject.Namespace).Get(context.TODO(), gvkr.GroupResource(), scaledObject.Spec.ScaleTargetRef.Name, metav1.GetOptions{})
+	if err != nil {
+		logger.Error(err, "Failed to get scaleTarget's scale status")
+		return err
+	}
+
+	if scale.Spec.Replicas != pausedReplicas {
+		currentReplicas := scale.Spec.Replicas
+		scale.Spec.Replicas = pausedReplicas
+		_, err = (*r.ScaleClient).Scales(scaledObject.Namespace).Update(context.TODO(), gvkr.GroupResource(), scale, metav1.UpdateOptions{})
+		if err != nil {
+			logger.Error(err, "Failed to scale scaleTarget to the paused replica count")
+			return err
+		}
+		logger.Info("Successfully scaled scaleTarget to the paused replica count", "Original Replicas Count", currentReplicas, "New Replicas Count", pausedReplicas)
+	}
+
+	return nil
+}
+
+// deleteHPA deletes the HPA of the ScaledObject, if it exists
+func (r *ScaledObjectReconciler) deleteHPA(logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject) error {
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: getHPAName(scaledObject), Namespace: scaledObject.Namespace}, hpa)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		logger.Error(err, "Failed to get HPA from cluster")
+		return err
+	}
+
+	if err := r.Client.Delete(context.TODO(), hpa); err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "Failed to delete HPA", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+		return err
+	}
+	logger.Info("Deleted HPA of paused ScaledObject", "HPA.Namespace", hpa.Namespace, "HPA.Name", hpa.Name)
+	return nil
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+func newPausedScaledObject(annotations map[string]string) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Annotations: annotations}}
+}
+
+func TestGetPausedReplicaCount(t *testing.T) {
+	replicas, err := getPausedReplicaCount(newPausedScaledObject(nil))
+	assert.NoError(t, err)
+	assert.Nil(t, replicas)
+
+	replicas, err = getPausedReplicaCount(newPausedScaledObject(map[string]string{kedav1alpha1.PausedReplicasAnnotation: "3"}))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), *replicas)
+
+	replicas, err = getPausedReplicaCount(newPausedScaledObject(map[string]string{kedav1alpha1.PausedReplicasAnnotation: "0"}))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *replicas)
+
+	_, err = getPausedReplicaCount(newPausedScaledObject(map[string]string{kedav1alpha1.PausedReplicasAnnotation: "a"}))
+	assert.Error(t, err)
+
+	_, err = getPausedReplicaCount(newPausedScaledObject(map[string]string{kedav1alpha1.PausedReplicasAnnotation: "-1"}))
+	assert.Error(t, err)
+}
+
+func TestPausedReplicasPredicate(t *testing.T) {
+	notPaused := newPausedScaledObject(map[string]string{"foo": "bar"})
+	paused := newPausedScaledObject(map[string]string{kedav1alpha1.PausedReplicasAnnotation: "1"})
+	pausedOther := newPausedScaledObject(map[string]string{kedav1alpha1.PausedReplicasAnnotation: "2"})
+	p := pausedReplicasPredicate{}
+
+	assert.True(t, p.Update(event.UpdateEvent{MetaOld: notPaused, ObjectOld: notPaused, MetaNew: paused, ObjectNew: paused}))
+	assert.True(t, p.Update(event.UpdateEvent{MetaOld: paused, ObjectOld: paused, MetaNew: notPaused, ObjectNew: notPaused}))
+	assert.True(t, p.Update(event.UpdateEvent{MetaOld: paused, ObjectOld: paused, MetaNew: pausedOther, ObjectNew: pausedOther}))
+	assert.False(t, p.Update(event.UpdateEvent{MetaOld: paused, ObjectOld: paused, MetaNew: paused, ObjectNew: paused}))
+	assert.False(t, p.Update(event.UpdateEvent{MetaOld: notPaused, ObjectOld: notPaused, MetaNew: notPaused, ObjectNew: notPaused}))
+}
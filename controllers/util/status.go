@@ -5,10 +5,12 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	kedautil "github.com/kedacore/keda/pkg/util"
 )
 
 // SetStatusConditions patches given object with passed list of conditions based on the object's type or returns an error.
@@ -23,6 +25,15 @@ func SetStatusConditions(client runtimeclient.StatusClient, logger logr.Logger,
 	case *kedav1alpha1.ScaledJob:
 		patch = runtimeclient.MergeFrom(obj.DeepCopy())
 		obj.Status.Conditions = *conditions
+	case *unstructured.Unstructured:
+		// custom resources with triggers may have conditions of their own, only the passed ones are replaced
+		patch = runtimeclient.MergeFrom(obj.DeepCopy())
+		for _, c := range *conditions {
+			if err := kedautil.SetUnstructuredCondition(obj, c.Type, c.Status, c.Reason, c.Message); err != nil {
+				logger.Error(err, "Failed to patch Objects Status with Conditions")
+				return err
+			}
+		}
 	default:
 		err := fmt.Errorf("unknown scalable object type %v", obj)
 		logger.Error(err, "Failed to patch Objects Status with Conditions")
@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	kedacontrollerutil "github.com/kedacore/keda/controllers/util"
+	"github.com/kedacore/keda/pkg/scaling"
+)
+
+// WithTriggersReconciler reconciles custom resources of a single kind, which implement the WithTriggers duck type,
+// ie. they have spec.triggers and optionally spec.pollingInterval. KEDA runs the scale loop for these resources
+// and reports the activity in status.lastActiveTime and the Active condition, the scaling itself is done by
+// the controller of the custom resource.
+type WithTriggersReconciler struct {
+	client.Client
+	Log          logr.Logger
+	ScaleHandler scaling.ScaleHandler
+	GVK          schema.GroupVersionKind
+}
+
+// SetupWithManager initializes the WithTriggersReconciler instance and starts a new controller managed by the passed Manager instance.
+func (r *WithTriggersReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(r.GVK)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(fmt.Sprintf("withtriggers-%s", strings.ToLower(r.GVK.GroupKind().String()))).
+		// Ignore updates to Status (in this case metadata.Generation does not change)
+		// so reconcile loop is not started on Status updates
+		For(object, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// Reconcile performs reconciliation on the identified custom resource based on the request information passed, returns the result and an error (if any).
+func (r *WithTriggersReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Log.WithValues("Kind", r.GVK.Kind, "Namespace", req.Namespace, "Name", req.Name)
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(r.GVK)
+	err := r.Client.Get(context.TODO(), req.NamespacedName, object)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The custom resource was deleted, there is no finalizer on resources not owned by KEDA,
+			// so the scale loop is stopped here. Kind, namespace and name identify the scale loop.
+			object.SetNamespace(req.Namespace)
+			object.SetName(req.Name)
+			reqLogger.Info("Stopping ScaleLoop of deleted resource")
+			return ctrl.Result{}, r.ScaleHandler.DeleteScalableObject(object)
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.Error(err, "Failed to get resource")
+		return ctrl.Result{}, err
+	}
+
+	reqLogger.Info("Reconciling resource with triggers")
+
+	msg, err := r.reconcileWithTriggers(reqLogger, object)
+	ready := kedav1alpha1.Condition{Type: kedav1alpha1.ConditionReady}
+	if err != nil {
+		reqLogger.Error(err, msg)
+		ready.Status, ready.Reason, ready.Message = metav1.ConditionFalse, "WithTriggersCheckFailed", msg
+	} else {
+		reqLogger.V(1).Info(msg)
+		ready.Status, ready.Reason, ready.Message = metav1.ConditionTrue, "WithTriggersReady", msg
+	}
+	kedacontrollerutil.SetStatusConditions(r.Client, reqLogger, object, &kedav1alpha1.Conditions{ready})
+
+	return ctrl.Result{}, err
+}
+
+// reconcileWithTriggers validates the triggers of the custom resource and (re)starts its ScaleLoop
+func (r *WithTriggersReconciler) reconcileWithTriggers(logger logr.Logger, object *unstructured.Unstructured) (string, error) {
+	triggers, _, err := unstructured.NestedSlice(object.Object, "spec", "triggers")
+	if err != nil {
+		return "Failed to read spec.triggers", err
+	}
+	if len(triggers) == 0 {
+		// stop a ScaleLoop of a previous version, which might have had triggers
+		if err := r.ScaleHandler.DeleteScalableObject(object); err != nil {
+			return "Failed to stop the scale loop", err
+		}
+		return "spec.triggers is empty", fmt.Errorf("no triggers defined in %s", object.GetKind())
+	}
+
+	if err := r.ScaleHandler.ValidateScalableObject(object); err != nil {
+		return "Triggers are not configured correctly", err
+	}
+
+	// resource was created or modified - let's start a new ScaleLoop
+	logger.V(1).Info("Starting a new ScaleLoop")
+	if err := r.ScaleHandler.HandleScalableObject(object); err != nil {
+		return "Failed to start a new scale loop with scaling logic", err
+	}
+	return "Resource with triggers is defined correctly and is ready for scaling", nil
+}
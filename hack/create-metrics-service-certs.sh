@@ -0,0 +1,34 @@
+#!/usr/bin/env bash
+
+# Creates the keda-metrics-service-certs secret with a self-signed CA and a certificate signed by it,
+# which is used by the KEDA Operator (gRPC Metrics Service) and the KEDA Metrics Server for mutual TLS.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+NAMESPACE=${NAMESPACE:-keda}
+SECRET_NAME=keda-metrics-service-certs
+
+if kubectl get secret "${SECRET_NAME}" --namespace "${NAMESPACE}" >/dev/null 2>&1; then
+  echo "secret ${NAMESPACE}/${SECRET_NAME} already exists"
+  exit 0
+fi
+
+_tmp=$(mktemp -d)
+trap 'rm -rf "${_tmp}"' EXIT
+
+openssl req -x509 -newkey rsa:2048 -nodes -days 365 -subj "/CN=keda-metrics-service-ca" \
+  -keyout "${_tmp}/ca.key" -out "${_tmp}/ca.crt"
+openssl req -newkey rsa:2048 -nodes -subj "/CN=keda-operator.${NAMESPACE}.svc" \
+  -keyout "${_tmp}/tls.key" -out "${_tmp}/tls.csr"
+cat > "${_tmp}/ext.cnf" <<EXT
+subjectAltName = DNS:keda-operator.${NAMESPACE}.svc.cluster.local, DNS:keda-operator.${NAMESPACE}.svc
+extendedKeyUsage = serverAuth, clientAuth
+EXT
+openssl x509 -req -days 365 -CA "${_tmp}/ca.crt" -CAkey "${_tmp}/ca.key" -CAcreateserial \
+  -extfile "${_tmp}/ext.cnf" -in "${_tmp}/tls.csr" -out "${_tmp}/tls.crt"
+
+kubectl create namespace "${NAMESPACE}" --dry-run=client -o yaml | kubectl apply -f -
+kubectl create secret generic "${SECRET_NAME}" --namespace "${NAMESPACE}" \
+  --from-file="${_tmp}/ca.crt" --from-file="${_tmp}/tls.crt" --from-file="${_tmp}/tls.key"
@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"os"
 	"runtime"
+	"strings"
 
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
@@ -32,6 +34,10 @@ import (
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
 	"github.com/kedacore/keda/controllers"
+	"github.com/kedacore/keda/pkg/metricsservice"
+	"github.com/kedacore/keda/pkg/scaling"
+	kedautil "github.com/kedacore/keda/pkg/util"
+	"github.com/kedacore/keda/pkg/webhooks"
 	"github.com/kedacore/keda/version"
 	// +kubebuilder:scaffold:imports
 )
@@ -49,11 +55,26 @@ func init() {
 
 func main() {
 	var metricsAddr string
+	var metricsServiceAddr string
 	var enableLeaderElection bool
+	var enableWebhooks bool
+	var webhooksCertDir string
+	var metricsServiceCertDir string
+	var scalableResources string
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&metricsServiceAddr, "metrics-service-bind-address", ":9666", "The address the gRPC Metrics Service endpoint binds to.")
+	flag.StringVar(&metricsServiceCertDir, "metrics-service-cert-dir", "/certs",
+		"The directory with the certificate of the gRPC Metrics Service (tls.crt and tls.key) and the CA (ca.crt) the certificate of the KEDA Metrics Server is verified with.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"Enable validating admission webhooks for ScaledObjects, ScaledJobs and TriggerAuthentications. "+
+			"The webhook server requires a serving certificate (tls.crt and tls.key) in the webhooks cert dir.")
+	flag.StringVar(&webhooksCertDir, "webhooks-cert-dir", "", "The directory with the serving certificate of the webhook server, defaults to /tmp/k8s-webhook-server/serving-certs.")
+	flag.StringVar(&scalableResources, "scalable-resources", "",
+		"Comma-separated list of custom resources with spec.triggers, which KEDA should run the scale loop for, in the form Kind.version.group. "+
+			"The operator needs RBAC permissions to get, list, watch and patch these resources and their status.")
 
 	// Add the zap logger flag set to the CLI.
 	opts := zap.Options{}
@@ -69,6 +90,7 @@ func main() {
 		MetricsBindAddress:     metricsAddr,
 		HealthProbeBindAddress: ":8081",
 		Port:                   9443,
+		CertDir:                webhooksCertDir,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "operator.keda.sh",
 	})
@@ -91,24 +113,66 @@ func main() {
 		os.Exit(1)
 	}
 
+	scaleClient, err := kedautil.InitScaleClient(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to init scale client")
+		os.Exit(1)
+	}
+
+	// ScaleHandler is shared by the controllers and the Metrics Service,
+	// so scalers are built and cached only once per ScaledObject/ScaledJob
+	scaleHandler := scaling.NewScaleHandler(mgr.GetClient(), mgr.GetAPIReader(), &scaleClient, mgr.GetScheme())
+
 	if err = (&controllers.ScaledObjectReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ScaledObject"),
-		Scheme: mgr.GetScheme(),
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName("controllers").WithName("ScaledObject"),
+		Scheme:       mgr.GetScheme(),
+		ScaleClient:  &scaleClient,
+		ScaleHandler: scaleHandler,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledObject")
 		os.Exit(1)
 	}
 	if err = (&controllers.ScaledJobReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ScaledJob"),
-		Scheme: mgr.GetScheme(),
+		Client:       mgr.GetClient(),
+		Log:          ctrl.Log.WithName("controllers").WithName("ScaledJob"),
+		Scheme:       mgr.GetScheme(),
+		ScaleHandler: scaleHandler,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ScaledJob")
 		os.Exit(1)
 	}
+	gvks, err := parseScalableResources(scalableResources)
+	if err != nil {
+		setupLog.Error(err, "invalid --scalable-resources")
+		os.Exit(1)
+	}
+	for _, gvk := range gvks {
+		if err = (&controllers.WithTriggersReconciler{
+			Client:       mgr.GetClient(),
+			Log:          ctrl.Log.WithName("controllers").WithName(gvk.Kind),
+			ScaleHandler: scaleHandler,
+			GVK:          gvk,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", gvk.Kind)
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if enableWebhooks {
+		if err = webhooks.SetupWebhooks(mgr, scaleHandler); err != nil {
+			setupLog.Error(err, "unable to set up webhooks")
+			os.Exit(1)
+		}
+	}
+
+	// Metrics Service serves scalers' metrics to the KEDA Metrics Server (adapter)
+	if err = mgr.Add(metricsservice.NewGrpcServer(mgr.GetClient(), scaleHandler, metricsServiceAddr, metricsServiceCertDir)); err != nil {
+		setupLog.Error(err, "unable to set up Metrics Service gRPC server")
+		os.Exit(1)
+	}
+
 	setupLog.Info("Starting manager")
 	setupLog.Info(fmt.Sprintf("KEDA Version: %s", version.Version))
 	setupLog.Info(fmt.Sprintf("KEDA Commit: %s", version.GitCommit))
@@ -120,3 +184,20 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// parseScalableResources parses the comma-separated list of custom resources in the form Kind.version.group
+func parseScalableResources(value string) ([]schema.GroupVersionKind, error) {
+	var gvks []schema.GroupVersionKind
+	for _, resource := range strings.Split(value, ",") {
+		resource = strings.TrimSpace(resource)
+		if resource == "" {
+			continue
+		}
+		gvk, _ := schema.ParseKindArg(resource)
+		if gvk == nil {
+			return nil, fmt.Errorf("resource %s must be specified in the form Kind.version.group", resource)
+		}
+		gvks = append(gvks, *gvk)
+	}
+	return gvks, nil
+}
@@ -0,0 +1,107 @@
+package fallback
+
+import (
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+var log = logf.Log.WithName("fallback")
+
+// IsEnabled returns true if spec.fallback is specified for the ScaledObject
+func IsEnabled(scaledObject *kedav1alpha1.ScaledObject) bool {
+	return scaledObject.Spec.Fallback != nil
+}
+
+// UpdateHealthStatus records the result of the last call to the metric in the status,
+// the number of failures is reset on success and incremented on error
+func UpdateHealthStatus(status *kedav1alpha1.ScaledObjectStatus, metricName string, err error) {
+	if status.Health == nil {
+		status.Health = make(map[string]kedav1alpha1.HealthStatus)
+	}
+
+	numberOfFailures := int32(0)
+	if healthStatus, ok := status.Health[metricName]; ok && healthStatus.NumberOfFailures != nil {
+		numberOfFailures = *healthStatus.NumberOfFailures
+	}
+
+	healthStatus := kedav1alpha1.HealthStatus{Status: kedav1alpha1.HealthStatusHappy}
+	if err != nil {
+		numberOfFailures++
+		healthStatus.Status = kedav1alpha1.HealthStatusFailing
+	} else {
+		numberOfFailures = 0
+	}
+	healthStatus.NumberOfFailures = &numberOfFailures
+
+	status.Health[metricName] = healthStatus
+}
+
+// PruneHealthStatus removes the health status of the metrics which are no longer among the given metric names,
+// eg. after a trigger was removed from the ScaledObject
+func PruneHealthStatus(status *kedav1alpha1.ScaledObjectStatus, metricNames []string) {
+	current := make(map[string]bool, len(metricNames))
+	for _, metricName := range metricNames {
+		current[metricName] = true
+	}
+	for metricName := range status.Health {
+		if !current[metricName] {
+			delete(status.Health, metricName)
+		}
+	}
+}
+
+// IsFailing returns true if the metric has failed at least spec.fallback.failureThreshold times in a row
+func IsFailing(scaledObject *kedav1alpha1.ScaledObject, metricName string) bool {
+	if !IsEnabled(scaledObject) {
+		return false
+	}
+
+	healthStatus, ok := scaledObject.Status.Health[metricName]
+	if !ok || healthStatus.NumberOfFailures == nil {
+		return false
+	}
+	return *healthStatus.NumberOfFailures >= scaledObject.Spec.Fallback.FailureThreshold
+}
+
+// HasFailingMetrics returns true if any metric of the ScaledObject has reached spec.fallback.failureThreshold
+func HasFailingMetrics(scaledObject *kedav1alpha1.ScaledObject) bool {
+	for metricName := range scaledObject.Status.Health {
+		if IsFailing(scaledObject, metricName) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMetricsWithFallback returns metrics of a scaler. If the scaler returned an error and its metric
+// has reached the failure threshold, a synthetic metric is returned instead, which keeps the HPA
+// at spec.fallback.replicas. Only metrics with AverageValue target can be used for the fallback.
+func GetMetricsWithFallback(scaledObject *kedav1alpha1.ScaledObject, metrics []external_metrics.ExternalMetricValue, suppressedError error, metricName string, metricSpec v2beta2.MetricSpec) ([]external_metrics.ExternalMetricValue, error) {
+	if suppressedError == nil {
+		return metrics, nil
+	}
+
+	if !IsFailing(scaledObject, metricName) {
+		return nil, suppressedError
+	}
+
+	if metricSpec.External == nil || metricSpec.External.Target.Type != v2beta2.AverageValueMetricType || metricSpec.External.Target.AverageValue == nil {
+		log.Info("Fallback is supported only for metrics with AverageValue target", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "metricName", metricName)
+		return nil, suppressedError
+	}
+
+	log.V(1).Info("Returning fallback metric", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "metricName", metricName, "replicas", scaledObject.Spec.Fallback.Replicas, "error", suppressedError.Error())
+
+	replicas := int64(scaledObject.Spec.Fallback.Replicas)
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(metricSpec.External.Target.AverageValue.MilliValue()*replicas, resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+	return []external_metrics.ExternalMetricValue{metric}, nil
+}
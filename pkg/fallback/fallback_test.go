@@ -0,0 +1,111 @@
+package fallback
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+const testMetricName = "some_metric_name"
+
+func newTestScaledObject(fallback *kedav1alpha1.Fallback, numberOfFailures int32) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Fallback: fallback,
+		},
+		Status: kedav1alpha1.ScaledObjectStatus{
+			Health: map[string]kedav1alpha1.HealthStatus{
+				testMetricName: {NumberOfFailures: &numberOfFailures, Status: kedav1alpha1.HealthStatusFailing},
+			},
+		},
+	}
+}
+
+func newTestMetricSpec(targetType v2beta2.MetricTargetType, target int64) v2beta2.MetricSpec {
+	metricTarget := v2beta2.MetricTarget{Type: targetType}
+	if targetType == v2beta2.AverageValueMetricType {
+		metricTarget.AverageValue = resource.NewQuantity(target, resource.DecimalSI)
+	} else {
+		metricTarget.Value = resource.NewQuantity(target, resource.DecimalSI)
+	}
+	return v2beta2.MetricSpec{
+		Type: v2beta2.ExternalMetricSourceType,
+		External: &v2beta2.ExternalMetricSource{
+			Metric: v2beta2.MetricIdentifier{Name: testMetricName},
+			Target: metricTarget,
+		},
+	}
+}
+
+func TestUpdateHealthStatus(t *testing.T) {
+	status := &kedav1alpha1.ScaledObjectStatus{}
+
+	UpdateHealthStatus(status, testMetricName, errors.New("some error"))
+	UpdateHealthStatus(status, testMetricName, errors.New("some error"))
+	assert.Equal(t, int32(2), *status.Health[testMetricName].NumberOfFailures)
+	assert.Equal(t, kedav1alpha1.HealthStatusFailing, status.Health[testMetricName].Status)
+
+	UpdateHealthStatus(status, testMetricName, nil)
+	assert.Equal(t, int32(0), *status.Health[testMetricName].NumberOfFailures)
+	assert.Equal(t, kedav1alpha1.HealthStatusHappy, status.Health[testMetricName].Status)
+}
+
+func TestPruneHealthStatus(t *testing.T) {
+	scaledObject := newTestScaledObject(&kedav1alpha1.Fallback{FailureThreshold: 3, Replicas: 10}, 5)
+	status := scaledObject.Status.DeepCopy()
+	UpdateHealthStatus(status, "other_metric_name", nil)
+
+	PruneHealthStatus(status, []string{"other_metric_name"})
+	assert.NotContains(t, status.Health, testMetricName)
+	assert.Contains(t, status.Health, "other_metric_name")
+
+	scaledObject.Status = *status
+	assert.False(t, HasFailingMetrics(scaledObject))
+}
+
+func TestReturnsMetricsWhenScalerSucceeds(t *testing.T) {
+	scaledObject := newTestScaledObject(&kedav1alpha1.Fallback{FailureThreshold: 3, Replicas: 10}, 5)
+	metrics := []external_metrics.ExternalMetricValue{{MetricName: testMetricName, Value: *resource.NewQuantity(4, resource.DecimalSI)}}
+
+	result, err := GetMetricsWithFallback(scaledObject, metrics, nil, testMetricName, newTestMetricSpec(v2beta2.AverageValueMetricType, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, metrics, result)
+}
+
+func TestReturnsErrorWhenFallbackIsNotEnabled(t *testing.T) {
+	scaledObject := newTestScaledObject(nil, 5)
+
+	_, err := GetMetricsWithFallback(scaledObject, nil, errors.New("some error"), testMetricName, newTestMetricSpec(v2beta2.AverageValueMetricType, 2))
+	assert.Error(t, err)
+}
+
+func TestReturnsErrorBelowFailureThreshold(t *testing.T) {
+	scaledObject := newTestScaledObject(&kedav1alpha1.Fallback{FailureThreshold: 3, Replicas: 10}, 2)
+
+	_, err := GetMetricsWithFallback(scaledObject, nil, errors.New("some error"), testMetricName, newTestMetricSpec(v2beta2.AverageValueMetricType, 2))
+	assert.Error(t, err)
+	assert.False(t, HasFailingMetrics(scaledObject))
+}
+
+func TestReturnsFallbackMetricWhenFailureThresholdIsReached(t *testing.T) {
+	scaledObject := newTestScaledObject(&kedav1alpha1.Fallback{FailureThreshold: 3, Replicas: 10}, 3)
+
+	result, err := GetMetricsWithFallback(scaledObject, nil, errors.New("some error"), testMetricName, newTestMetricSpec(v2beta2.AverageValueMetricType, 2))
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(20), result[0].Value.Value())
+	assert.True(t, HasFailingMetrics(scaledObject))
+}
+
+func TestReturnsErrorForValueMetricType(t *testing.T) {
+	scaledObject := newTestScaledObject(&kedav1alpha1.Fallback{FailureThreshold: 3, Replicas: 10}, 3)
+
+	_, err := GetMetricsWithFallback(scaledObject, nil, errors.New("some error"), testMetricName, newTestMetricSpec(v2beta2.ValueMetricType, 2))
+	assert.Error(t, err)
+}
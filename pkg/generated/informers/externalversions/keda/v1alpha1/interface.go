@@ -24,6 +24,8 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
+	ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer
 	// ScaledJobs returns a ScaledJobInformer.
 	ScaledJobs() ScaledJobInformer
 	// ScaledObjects returns a ScaledObjectInformer.
@@ -43,6 +45,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterTriggerAuthentications returns a ClusterTriggerAuthenticationInformer.
+func (v *version) ClusterTriggerAuthentications() ClusterTriggerAuthenticationInformer {
+	return &clusterTriggerAuthenticationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ScaledJobs returns a ScaledJobInformer.
 func (v *version) ScaledJobs() ScaledJobInformer {
 	return &scaledJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
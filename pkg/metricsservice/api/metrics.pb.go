@@ -0,0 +1,396 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: metrics.proto
+
+package api
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ScaledObjectRef struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	MetricName           string   `protobuf:"bytes,3,opt,name=metricName,proto3" json:"metricName,omitempty"`
+	MetricSelector       string   `protobuf:"bytes,4,opt,name=metricSelector,proto3" json:"metricSelector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScaledObjectRef) Reset()         { *m = ScaledObjectRef{} }
+func (m *ScaledObjectRef) String() string { return proto.CompactTextString(m) }
+func (*ScaledObjectRef) ProtoMessage()    {}
+func (*ScaledObjectRef) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6039342a2ba47b72, []int{0}
+}
+
+func (m *ScaledObjectRef) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScaledObjectRef.Unmarshal(m, b)
+}
+func (m *ScaledObjectRef) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScaledObjectRef.Marshal(b, m, deterministic)
+}
+func (m *ScaledObjectRef) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScaledObjectRef.Merge(m, src)
+}
+func (m *ScaledObjectRef) XXX_Size() int {
+	return xxx_messageInfo_ScaledObjectRef.Size(m)
+}
+func (m *ScaledObjectRef) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScaledObjectRef.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScaledObjectRef proto.InternalMessageInfo
+
+func (m *ScaledObjectRef) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ScaledObjectRef) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ScaledObjectRef) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
+}
+
+func (m *ScaledObjectRef) GetMetricSelector() string {
+	if m != nil {
+		return m.MetricSelector
+	}
+	return ""
+}
+
+type Response struct {
+	Metrics              []*MetricValue `protobuf:"bytes,1,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	Errors               []*ScalerError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return proto.CompactTextString(m) }
+func (*Response) ProtoMessage()    {}
+func (*Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6039342a2ba47b72, []int{1}
+}
+
+func (m *Response) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Response.Unmarshal(m, b)
+}
+func (m *Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Response.Marshal(b, m, deterministic)
+}
+func (m *Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Response.Merge(m, src)
+}
+func (m *Response) XXX_Size() int {
+	return xxx_messageInfo_Response.Size(m)
+}
+func (m *Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_Response.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Response proto.InternalMessageInfo
+
+func (m *Response) GetMetrics() []*MetricValue {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+func (m *Response) GetErrors() []*ScalerError {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
+}
+
+type MetricValue struct {
+	MetricName           string            `protobuf:"bytes,1,opt,name=metricName,proto3" json:"metricName,omitempty"`
+	MetricLabels         map[string]string `protobuf:"bytes,2,rep,name=metricLabels,proto3" json:"metricLabels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Timestamp            int64             `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value                string            `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	ScalerName           string            `protobuf:"bytes,5,opt,name=scalerName,proto3" json:"scalerName,omitempty"`
+	ScalerIndex          int32             `protobuf:"varint,6,opt,name=scalerIndex,proto3" json:"scalerIndex,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *MetricValue) Reset()         { *m = MetricValue{} }
+func (m *MetricValue) String() string { return proto.CompactTextString(m) }
+func (*MetricValue) ProtoMessage()    {}
+func (*MetricValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6039342a2ba47b72, []int{2}
+}
+
+func (m *MetricValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MetricValue.Unmarshal(m, b)
+}
+func (m *MetricValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MetricValue.Marshal(b, m, deterministic)
+}
+func (m *MetricValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricValue.Merge(m, src)
+}
+func (m *MetricValue) XXX_Size() int {
+	return xxx_messageInfo_MetricValue.Size(m)
+}
+func (m *MetricValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricValue proto.InternalMessageInfo
+
+func (m *MetricValue) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
+}
+
+func (m *MetricValue) GetMetricLabels() map[string]string {
+	if m != nil {
+		return m.MetricLabels
+	}
+	return nil
+}
+
+func (m *MetricValue) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *MetricValue) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *MetricValue) GetScalerName() string {
+	if m != nil {
+		return m.ScalerName
+	}
+	return ""
+}
+
+func (m *MetricValue) GetScalerIndex() int32 {
+	if m != nil {
+		return m.ScalerIndex
+	}
+	return 0
+}
+
+type ScalerError struct {
+	MetricName           string   `protobuf:"bytes,1,opt,name=metricName,proto3" json:"metricName,omitempty"`
+	ScalerName           string   `protobuf:"bytes,2,opt,name=scalerName,proto3" json:"scalerName,omitempty"`
+	ScalerIndex          int32    `protobuf:"varint,3,opt,name=scalerIndex,proto3" json:"scalerIndex,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ScalerError) Reset()         { *m = ScalerError{} }
+func (m *ScalerError) String() string { return proto.CompactTextString(m) }
+func (*ScalerError) ProtoMessage()    {}
+func (*ScalerError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6039342a2ba47b72, []int{3}
+}
+
+func (m *ScalerError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScalerError.Unmarshal(m, b)
+}
+func (m *ScalerError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScalerError.Marshal(b, m, deterministic)
+}
+func (m *ScalerError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScalerError.Merge(m, src)
+}
+func (m *ScalerError) XXX_Size() int {
+	return xxx_messageInfo_ScalerError.Size(m)
+}
+func (m *ScalerError) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScalerError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScalerError proto.InternalMessageInfo
+
+func (m *ScalerError) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
+}
+
+func (m *ScalerError) GetScalerName() string {
+	if m != nil {
+		return m.ScalerName
+	}
+	return ""
+}
+
+func (m *ScalerError) GetScalerIndex() int32 {
+	if m != nil {
+		return m.ScalerIndex
+	}
+	return 0
+}
+
+func (m *ScalerError) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ScaledObjectRef)(nil), "api.ScaledObjectRef")
+	proto.RegisterType((*Response)(nil), "api.Response")
+	proto.RegisterType((*MetricValue)(nil), "api.MetricValue")
+	proto.RegisterMapType((map[string]string)(nil), "api.MetricValue.MetricLabelsEntry")
+	proto.RegisterType((*ScalerError)(nil), "api.ScalerError")
+}
+
+func init() { proto.RegisterFile("metrics.proto", fileDescriptor_6039342a2ba47b72) }
+
+var fileDescriptor_6039342a2ba47b72 = []byte{
+	// 386 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x93, 0xcf, 0x6a, 0xe3, 0x30,
+	0x10, 0xc6, 0x57, 0x76, 0x9c, 0x6c, 0xc6, 0x9b, 0x6c, 0x56, 0xe4, 0x60, 0xc2, 0xb2, 0x18, 0x1f,
+	0x16, 0xd3, 0x83, 0x0f, 0xc9, 0xa5, 0xb4, 0x87, 0x42, 0x21, 0x2d, 0x85, 0xa6, 0x05, 0x05, 0x7a,
+	0xe8, 0xa9, 0x8a, 0x33, 0x05, 0xb7, 0xfe, 0x87, 0xa4, 0x86, 0xe6, 0x01, 0x7a, 0xea, 0xab, 0xf4,
+	0x21, 0x8b, 0x65, 0x1b, 0x3b, 0xc9, 0x21, 0x27, 0x6b, 0xbe, 0x19, 0x8d, 0x7e, 0xf3, 0x49, 0x86,
+	0x41, 0x82, 0x4a, 0x44, 0xa1, 0x0c, 0x72, 0x91, 0xa9, 0x8c, 0x9a, 0x3c, 0x8f, 0xbc, 0x4f, 0x02,
+	0xbf, 0x97, 0x21, 0x8f, 0x71, 0x7d, 0xbf, 0x7a, 0xc1, 0x50, 0x31, 0x7c, 0xa6, 0x14, 0x3a, 0x29,
+	0x4f, 0xd0, 0x21, 0x2e, 0xf1, 0xfb, 0x4c, 0xaf, 0xe9, 0x5f, 0xe8, 0x17, 0x5f, 0x99, 0xf3, 0x10,
+	0x1d, 0x43, 0x27, 0x1a, 0x81, 0xfe, 0x03, 0x28, 0x7b, 0xdf, 0x15, 0xfb, 0x4c, 0x9d, 0x6e, 0x29,
+	0xf4, 0x3f, 0x0c, 0xcb, 0x68, 0x89, 0x31, 0x86, 0x2a, 0x13, 0x4e, 0x47, 0xd7, 0xec, 0xa9, 0xde,
+	0x13, 0xfc, 0x64, 0x28, 0xf3, 0x2c, 0x95, 0x48, 0x4f, 0xa0, 0x57, 0xf1, 0x3a, 0xc4, 0x35, 0x7d,
+	0x7b, 0x3a, 0x0a, 0x78, 0x1e, 0x05, 0x0b, 0xad, 0x3d, 0xf0, 0xf8, 0x0d, 0x59, 0x5d, 0x40, 0x7d,
+	0xe8, 0xa2, 0x10, 0x99, 0x90, 0x8e, 0xd1, 0x2a, 0xd5, 0x73, 0x89, 0x79, 0x91, 0x60, 0x55, 0xde,
+	0xfb, 0x32, 0xc0, 0x6e, 0xb5, 0xd8, 0x23, 0x27, 0x07, 0xe4, 0x57, 0xf0, 0xab, 0x8c, 0x6e, 0xf9,
+	0x0a, 0xe3, 0xba, 0xbf, 0xb7, 0x8f, 0x12, 0x2c, 0x5a, 0x45, 0xf3, 0x54, 0x89, 0x2d, 0xdb, 0xd9,
+	0x57, 0xf8, 0xa7, 0xa2, 0x04, 0xa5, 0xe2, 0x49, 0xae, 0x0d, 0x32, 0x59, 0x23, 0xd0, 0x31, 0x58,
+	0x9b, 0xa2, 0x4d, 0x65, 0x8b, 0xb5, 0xa9, 0xd9, 0xa4, 0x1e, 0x41, 0xb3, 0x59, 0x25, 0x5b, 0xa3,
+	0x50, 0x17, 0xec, 0x32, 0xba, 0x49, 0xd7, 0xf8, 0xee, 0x74, 0x5d, 0xe2, 0x5b, 0xac, 0x2d, 0x4d,
+	0x2e, 0xe0, 0xcf, 0x01, 0x18, 0x1d, 0x81, 0xf9, 0x8a, 0xdb, 0x6a, 0xd6, 0x62, 0xd9, 0x1c, 0x6f,
+	0xb4, 0x8e, 0x3f, 0x33, 0x4e, 0x89, 0xf7, 0x41, 0xc0, 0x6e, 0xd9, 0x78, 0xd4, 0xae, 0x5d, 0x64,
+	0xe3, 0x18, 0xb2, 0x79, 0x80, 0x5c, 0xb0, 0xe8, 0xab, 0xaa, 0xad, 0xd0, 0xc1, 0x74, 0x0e, 0xc3,
+	0x72, 0x10, 0xb9, 0x44, 0xb1, 0x89, 0x42, 0xa4, 0x33, 0x80, 0x6b, 0x54, 0x95, 0x48, 0xc7, 0xcd,
+	0x85, 0x37, 0x0f, 0x79, 0x32, 0xd0, 0x6a, 0xfd, 0xa2, 0xbc, 0x1f, 0x97, 0xbd, 0x47, 0x2b, 0x38,
+	0xe7, 0x79, 0xb4, 0xea, 0xea, 0x5f, 0x60, 0xf6, 0x3d, 0x00, 0x12, 0x9d, 0xfd, 0xd9, 0x13, 0x03,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// MetricsServiceClient is the client API for MetricsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type MetricsServiceClient interface {
+	GetMetrics(ctx context.Context, in *ScaledObjectRef, opts ...grpc.CallOption) (*Response, error)
+}
+
+type metricsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMetricsServiceClient(cc *grpc.ClientConn) MetricsServiceClient {
+	return &metricsServiceClient{cc}
+}
+
+func (c *metricsServiceClient) GetMetrics(ctx context.Context, in *ScaledObjectRef, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	err := c.cc.Invoke(ctx, "/api.MetricsService/GetMetrics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MetricsServiceServer is the server API for MetricsService service.
+type MetricsServiceServer interface {
+	GetMetrics(context.Context, *ScaledObjectRef) (*Response, error)
+}
+
+// UnimplementedMetricsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedMetricsServiceServer struct {
+}
+
+func (*UnimplementedMetricsServiceServer) GetMetrics(ctx context.Context, req *ScaledObjectRef) (*Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
+}
+
+func RegisterMetricsServiceServer(s *grpc.Server, srv MetricsServiceServer) {
+	s.RegisterService(&_MetricsService_serviceDesc, srv)
+}
+
+func _MetricsService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScaledObjectRef)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetricsServiceServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.MetricsService/GetMetrics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetricsServiceServer).GetMetrics(ctx, req.(*ScaledObjectRef))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MetricsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "api.MetricsService",
+	HandlerType: (*MetricsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMetrics",
+			Handler:    _MetricsService_GetMetrics_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "metrics.proto",
+}
@@ -0,0 +1,66 @@
+package metricsservice
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+)
+
+const (
+	// caCertFile is the CA of the certificates of the Metrics Service and of the Metrics Server (adapter)
+	caCertFile = "ca.crt"
+	certFile   = "tls.crt"
+	keyFile    = "tls.key"
+)
+
+// loadCertificates loads the key pair and the CA pool from certDir, the certificates of both sides of the
+// connection have to be signed by this CA
+func loadCertificates(certDir string) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join(certDir, certFile), filepath.Join(certDir, keyFile))
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("error loading the certificate from %s: %s", certDir, err)
+	}
+
+	ca, err := ioutil.ReadFile(filepath.Join(certDir, caCertFile))
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("error reading the CA certificate from %s: %s", certDir, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, fmt.Errorf("no CA certificate found in %s", filepath.Join(certDir, caCertFile))
+	}
+
+	return cert, pool, nil
+}
+
+// newServerTLSConfig returns the TLS config of the Metrics Service, only clients with a certificate signed by the CA are accepted
+func newServerTLSConfig(certDir string) (*tls.Config, error) {
+	cert, pool, err := loadCertificates(certDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// newClientTLSConfig returns the TLS config of the Metrics Server (adapter), the certificate of the Metrics Service
+// is verified against the CA
+func newClientTLSConfig(certDir string) (*tls.Config, error) {
+	cert, pool, err := loadCertificates(certDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package metricsservice
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/metricsservice/api"
+	"github.com/kedacore/keda/pkg/metricsservice/metricsservicetest"
+)
+
+func startTestServer(t *testing.T, certDir string) string {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	tlsConfig, err := newServerTLSConfig(certDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	api.RegisterMetricsServiceServer(server, &GrpcServer{
+		client:       fake.NewFakeClientWithScheme(scheme),
+		scaleHandler: &fakeScaleHandler{},
+		logger:       logf.Log.WithName("test"),
+	})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestGrpcClientWithCertificate(t *testing.T) {
+	certDir := t.TempDir()
+	if err := metricsservicetest.WriteCertificates(certDir); err != nil {
+		t.Fatal(err)
+	}
+	address := startTestServer(t, certDir)
+
+	client, err := NewGrpcClient(address, certDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// the request reaches the server, which doesn't find the ScaledObject
+	_, err = client.GetMetrics(context.TODO(), "missing", "namespace", "metric", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error getting ScaledObject")
+}
+
+func TestGrpcServerRejectsClientWithoutCertificate(t *testing.T) {
+	certDir := t.TempDir()
+	if err := metricsservicetest.WriteCertificates(certDir); err != nil {
+		t.Fatal(err)
+	}
+	address := startTestServer(t, certDir)
+
+	clientConfig, err := newClientTLSConfig(certDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: clientConfig.RootCAs})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = api.NewMetricsServiceClient(conn).GetMetrics(context.TODO(), &api.ScaledObjectRef{Name: "missing", Namespace: "namespace"})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "error getting ScaledObject")
+}
+
+func TestGrpcClientWithoutCertificates(t *testing.T) {
+	_, err := NewGrpcClient("127.0.0.1:9666", t.TempDir())
+	assert.Error(t, err)
+}
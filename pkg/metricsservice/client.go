@@ -0,0 +1,57 @@
+package metricsservice
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kedacore/keda/pkg/metricsservice/api"
+)
+
+// GrpcClient requests metrics from the operator's Metrics Service
+type GrpcClient struct {
+	client     api.MetricsServiceClient
+	connection *grpc.ClientConn
+}
+
+// NewGrpcClient creates a new GrpcClient connected to the Metrics Service on the passed address,
+// it authenticates with the client certificate (tls.crt, tls.key) in certDir and verifies the server against ca.crt
+func NewGrpcClient(address string, certDir string) (*GrpcClient, error) {
+	tlsConfig, err := newClientTLSConfig(certDir)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to Metrics Service %s: %s", address, err)
+	}
+
+	return &GrpcClient{
+		client:     api.NewMetricsServiceClient(conn),
+		connection: conn,
+	}, nil
+}
+
+// GetMetrics returns the requested metric of the specified ScaledObject as it is computed by the operator
+func (c *GrpcClient) GetMetrics(ctx context.Context, scaledObjectName, scaledObjectNamespace, metricName string, metricSelector labels.Selector) (*api.Response, error) {
+	selector := ""
+	if metricSelector != nil {
+		selector = metricSelector.String()
+	}
+
+	return c.client.GetMetrics(ctx, &api.ScaledObjectRef{
+		Name:           scaledObjectName,
+		Namespace:      scaledObjectNamespace,
+		MetricName:     metricName,
+		MetricSelector: selector,
+	})
+}
+
+// Close closes the underlying gRPC connection
+func (c *GrpcClient) Close() error {
+	return c.connection.Close()
+}
@@ -0,0 +1,118 @@
+package metricsservice
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/metricsservice/api"
+	"github.com/kedacore/keda/pkg/scaling"
+)
+
+// GrpcServer serves metrics of the operator's scalers to the Metrics Server (adapter)
+type GrpcServer struct {
+	server       *grpc.Server
+	address      string
+	certDir      string
+	client       client.Client
+	scaleHandler scaling.ScaleHandler
+	logger       logr.Logger
+}
+
+// NewGrpcServer creates a new GrpcServer listening on the passed address, it serves the certificate (tls.crt, tls.key)
+// in certDir and only accepts clients with a certificate signed by ca.crt
+func NewGrpcServer(client client.Client, scaleHandler scaling.ScaleHandler, address string, certDir string) *GrpcServer {
+	return &GrpcServer{
+		address:      address,
+		certDir:      certDir,
+		client:       client,
+		scaleHandler: scaleHandler,
+		logger:       logf.Log.WithName("grpc_server"),
+	}
+}
+
+// GetMetrics returns metrics for the ScaledObject and metric name specified in the request
+func (s *GrpcServer) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*api.Response, error) {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	err := s.client.Get(ctx, types.NamespacedName{Name: in.Name, Namespace: in.Namespace}, scaledObject)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ScaledObject %s/%s: %s", in.Namespace, in.Name, err)
+	}
+
+	metricSelector, err := labels.Parse(in.MetricSelector)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing metricSelector %s: %s", in.MetricSelector, err)
+	}
+
+	results, err := s.scaleHandler.GetScaledObjectMetrics(ctx, scaledObject, in.MetricName, metricSelector)
+	if err != nil {
+		s.logger.Error(err, "error getting metrics for ScaledObject", "scaledObject.Namespace", in.Namespace, "scaledObject.Name", in.Name, "metricName", in.MetricName)
+		return nil, err
+	}
+
+	response := &api.Response{}
+	for _, result := range results {
+		if result.Err != nil {
+			response.Errors = append(response.Errors, &api.ScalerError{
+				MetricName:  in.MetricName,
+				ScalerName:  result.ScalerName,
+				ScalerIndex: int32(result.ScalerIndex),
+				Error:       result.Err.Error(),
+			})
+			continue
+		}
+
+		for _, metric := range result.Metrics {
+			response.Metrics = append(response.Metrics, &api.MetricValue{
+				MetricName:   metric.MetricName,
+				MetricLabels: metric.MetricLabels,
+				Timestamp:    metric.Timestamp.Unix(),
+				Value:        metric.Value.String(),
+				ScalerName:   result.ScalerName,
+				ScalerIndex:  int32(result.ScalerIndex),
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// Start starts the gRPC server and blocks until the stop channel is closed, it implements manager.Runnable
+func (s *GrpcServer) Start(stop <-chan struct{}) error {
+	tlsConfig, err := newServerTLSConfig(s.certDir)
+	if err != nil {
+		s.logger.Error(err, "failed to load the certificates of the Metrics Service", "certDir", s.certDir)
+		return err
+	}
+	s.server = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		s.logger.Error(err, "failed to listen", "address", s.address)
+		return err
+	}
+
+	api.RegisterMetricsServiceServer(s.server, s)
+
+	go func() {
+		<-stop
+		s.server.GracefulStop()
+	}()
+
+	s.logger.Info("Starting Metrics Service gRPC Server", "address", s.address)
+	return s.server.Serve(listener)
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, metrics are served by every operator replica
+func (s *GrpcServer) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,71 @@
+package metricsservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/metricsservice/api"
+	"github.com/kedacore/keda/pkg/scaling"
+	"github.com/kedacore/keda/pkg/scaling/cache"
+)
+
+type fakeScaleHandler struct {
+	results []scaling.ScalerMetricsResult
+}
+
+func (h *fakeScaleHandler) HandleScalableObject(scalableObject interface{}) error { return nil }
+func (h *fakeScaleHandler) DeleteScalableObject(scalableObject interface{}) error { return nil }
+func (h *fakeScaleHandler) GetScalersCache(scalableObject interface{}) (*cache.ScalersCache, error) {
+	return nil, nil
+}
+func (h *fakeScaleHandler) ClearScalersCache(scalableObject interface{}) error { return nil }
+func (h *fakeScaleHandler) ValidateScalableObject(scalableObject interface{}) error { return nil }
+func (h *fakeScaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metricName string, metricSelector labels.Selector) ([]scaling.ScalerMetricsResult, error) {
+	return h.results, nil
+}
+
+func TestGetMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	scaledObject := &kedav1alpha1.ScaledObject{ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"}}
+
+	server := &GrpcServer{
+		client: fake.NewFakeClientWithScheme(scheme, scaledObject),
+		scaleHandler: &fakeScaleHandler{results: []scaling.ScalerMetricsResult{
+			{
+				ScalerName:  "kafkaScaler",
+				ScalerIndex: 0,
+				Metrics:     []external_metrics.ExternalMetricValue{{MetricName: "metric", Value: *resource.NewQuantity(5, resource.DecimalSI)}},
+			},
+			{
+				ScalerName:  "redisScaler",
+				ScalerIndex: 1,
+				Err:         errors.New("connection refused"),
+			},
+		}},
+		logger: logf.Log.WithName("test"),
+	}
+
+	response, err := server.GetMetrics(context.TODO(), &api.ScaledObjectRef{Name: "name", Namespace: "namespace", MetricName: "metric"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Metrics, 1)
+	assert.Equal(t, "5", response.Metrics[0].Value)
+	assert.Equal(t, "kafkaScaler", response.Metrics[0].ScalerName)
+	assert.Len(t, response.Errors, 1)
+	assert.Equal(t, int32(1), response.Errors[0].ScalerIndex)
+	assert.Equal(t, "connection refused", response.Errors[0].Error)
+
+	_, err = server.GetMetrics(context.TODO(), &api.ScaledObjectRef{Name: "missing", Namespace: "namespace", MetricName: "metric"})
+	assert.Error(t, err)
+}
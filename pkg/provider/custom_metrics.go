@@ -0,0 +1,325 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubernetes-incubator/custom-metrics-apiserver/pkg/provider"
+	"github.com/kubernetes-incubator/custom-metrics-apiserver/pkg/provider/helpers"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/metrics/pkg/apis/custom_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+// maxOwnerDepth limits how many controller owners are followed from an object to the scale target of a ScaledObject,
+// eg. Pod -> ReplicaSet -> Deployment
+const maxOwnerDepth = 3
+
+var (
+	scaledObjectGroupResource = kedav1alpha1.Resource("scaledobjects")
+	podGroupResource          = schema.GroupResource{Resource: "pods"}
+)
+
+// GetMetricByName fetches a particular metric for a particular object.
+// The namespace will be empty if the metric is root-scoped.
+// The object can be the ScaledObject itself, its scale target or any object controlled by the scale target (eg. Pods),
+// the value of the metric is the value of the ScaledObject's metric
+func (p *KedaProvider) GetMetricByName(name types.NamespacedName, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValue, error) {
+	logger.V(1).Info("Keda provider received request for custom metric", "groupresource", info.GroupResource.String(), "namespace", name.Namespace, "name", name.Name, "metric name", info.Metric)
+	if name.Namespace == "" {
+		return nil, provider.NewMetricNotFoundForError(info.GroupResource, info.Metric, name.Name)
+	}
+
+	scaledObject, err := p.newScaledObjectResolver(name.Namespace).find(info.GroupResource, name.Name)
+	if err != nil {
+		return nil, err
+	}
+	if scaledObject == nil || !hasExternalMetric(scaledObject, info.Metric) {
+		return nil, provider.NewMetricNotFoundForError(info.GroupResource, info.Metric, name.Name)
+	}
+
+	metrics, err := p.getScaledObjectMetrics(scaledObject, info.Metric, metricSelector)
+	if err != nil {
+		return nil, err
+	}
+	if len(metrics) == 0 {
+		return nil, provider.NewMetricNotFoundForError(info.GroupResource, info.Metric, name.Name)
+	}
+
+	return p.newCustomMetricValue(name, info, metricSelector, metrics[0].Timestamp, metrics[0].Value)
+}
+
+// GetMetricBySelector fetches a particular metric for a set of objects matching
+// the given label selector.  The namespace will be empty if the metric is root-scoped.
+// Objects that don't belong to any ScaledObject with the metric are skipped. The metric is requested once
+// per ScaledObject and its value is split evenly between the matching objects of the ScaledObject, as the HPA
+// averages the values of Pods metrics.
+func (p *KedaProvider) GetMetricBySelector(namespace string, selector labels.Selector, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValueList, error) {
+	logger.V(1).Info("Keda provider received request for custom metric", "groupresource", info.GroupResource.String(), "namespace", namespace, "metric name", info.Metric, "selector", selector.String())
+	if namespace == "" {
+		return nil, provider.NewMetricNotFoundError(info.GroupResource, info.Metric)
+	}
+
+	gvk, err := p.mapper.KindFor(info.GroupResource.WithVersion(""))
+	if err != nil {
+		return nil, err
+	}
+	objects := &unstructured.UnstructuredList{}
+	objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := p.client.List(context.TODO(), objects, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	// group the objects by their ScaledObject
+	resolver := p.newScaledObjectResolver(namespace)
+	var scaledObjects []*kedav1alpha1.ScaledObject
+	objectNames := map[*kedav1alpha1.ScaledObject][]string{}
+	for _, object := range objects.Items {
+		scaledObject, err := resolver.find(info.GroupResource, object.GetName())
+		if err != nil {
+			return nil, err
+		}
+		if scaledObject == nil || !hasExternalMetric(scaledObject, info.Metric) {
+			continue
+		}
+		if _, ok := objectNames[scaledObject]; !ok {
+			scaledObjects = append(scaledObjects, scaledObject)
+		}
+		objectNames[scaledObject] = append(objectNames[scaledObject], object.GetName())
+	}
+
+	metricValues := []custom_metrics.MetricValue{}
+	for _, scaledObject := range scaledObjects {
+		metrics, err := p.getScaledObjectMetrics(scaledObject, info.Metric, metricSelector)
+		if err != nil {
+			return nil, err
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+
+		names := objectNames[scaledObject]
+		value := splitQuantity(metrics[0].Value, len(names))
+		for _, name := range names {
+			metricValue, err := p.newCustomMetricValue(types.NamespacedName{Namespace: namespace, Name: name}, info, metricSelector, metrics[0].Timestamp, value)
+			if err != nil {
+				return nil, err
+			}
+			metricValues = append(metricValues, *metricValue)
+		}
+	}
+
+	if len(metricValues) == 0 {
+		return nil, provider.NewMetricNotFoundForSelectorError(info.GroupResource, info.Metric, "", selector)
+	}
+
+	return &custom_metrics.MetricValueList{
+		Items: metricValues,
+	}, nil
+}
+
+// ListAllMetrics provides a list of all available metrics at
+// the current time.  Note that this is not allowed to return
+// an error, so it is recommended that implementors cache and
+// periodically update this list, instead of querying every time.
+// Metrics of each ScaledObject are listed for the ScaledObject, its scale target and Pods.
+func (p *KedaProvider) ListAllMetrics() []provider.CustomMetricInfo {
+	customMetricsInfo := []provider.CustomMetricInfo{}
+
+	//get all ScaledObjects in namespace(s) watched by the operator
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	err := p.client.List(context.TODO(), scaledObjects, client.InNamespace(p.watchedNamespace))
+	if err != nil {
+		logger.Error(err, "Cannot get list of ScaledObjects", "WatchedNamespace", p.watchedNamespace)
+		return customMetricsInfo
+	}
+
+	found := make(map[provider.CustomMetricInfo]bool)
+	for _, scaledObject := range scaledObjects.Items {
+		groupResources := []schema.GroupResource{scaledObjectGroupResource, podGroupResource}
+		if scaledObject.Status.ScaleTargetGVKR != nil {
+			groupResources = append(groupResources, scaledObject.Status.ScaleTargetGVKR.GroupResource())
+		}
+
+		for _, metric := range scaledObject.Status.ExternalMetricNames {
+			for _, groupResource := range groupResources {
+				info := provider.CustomMetricInfo{GroupResource: groupResource, Namespaced: true, Metric: metric}
+				if !found[info] {
+					found[info] = true
+					customMetricsInfo = append(customMetricsInfo, info)
+				}
+			}
+		}
+	}
+	return customMetricsInfo
+}
+
+func (p *KedaProvider) newCustomMetricValue(name types.NamespacedName, info provider.CustomMetricInfo, metricSelector labels.Selector, timestamp metav1.Time, value resource.Quantity) (*custom_metrics.MetricValue, error) {
+	objectRef, err := helpers.ReferenceFor(p.mapper, name, info)
+	if err != nil {
+		return nil, err
+	}
+
+	metricValue := &custom_metrics.MetricValue{
+		DescribedObject: objectRef,
+		Metric: custom_metrics.MetricIdentifier{
+			Name: info.Metric,
+		},
+		Timestamp: timestamp,
+		Value:     value,
+	}
+	if metricSelector != nil && !metricSelector.Empty() {
+		selector, err := metav1.ParseToLabelSelector(metricSelector.String())
+		if err == nil {
+			metricValue.Metric.Selector = selector
+		}
+	}
+	return metricValue, nil
+}
+
+// splitQuantity returns the share of each of count objects in the value
+func splitQuantity(value resource.Quantity, count int) resource.Quantity {
+	if count <= 1 {
+		return value
+	}
+	return *resource.NewMilliQuantity(value.MilliValue()/int64(count), value.Format)
+}
+
+// scaledObjectResolver finds the ScaledObjects of objects in a namespace, the ScaledObjects are listed once
+// and the results of all visited objects are remembered, so eg. the Pods of a ReplicaSet share a single owner walk
+type scaledObjectResolver struct {
+	p             *KedaProvider
+	namespace     string
+	scaledObjects *kedav1alpha1.ScaledObjectList
+	resolved      map[resolvedObject]*kedav1alpha1.ScaledObject
+}
+
+type resolvedObject struct {
+	groupResource schema.GroupResource
+	name          string
+}
+
+func (p *KedaProvider) newScaledObjectResolver(namespace string) *scaledObjectResolver {
+	return &scaledObjectResolver{
+		p:         p,
+		namespace: namespace,
+		resolved:  map[resolvedObject]*kedav1alpha1.ScaledObject{},
+	}
+}
+
+// find returns the ScaledObject the object belongs to, the object is either the ScaledObject, its scale target
+// or an object controlled (directly or indirectly) by the scale target. Nil is returned if there is no such ScaledObject.
+func (r *scaledObjectResolver) find(groupResource schema.GroupResource, name string) (*kedav1alpha1.ScaledObject, error) {
+	if groupResource == scaledObjectGroupResource {
+		if r.scaledObjects == nil {
+			// a single ScaledObject is read directly instead of listing all of them
+			scaledObject := &kedav1alpha1.ScaledObject{}
+			if err := r.p.client.Get(context.TODO(), types.NamespacedName{Namespace: r.namespace, Name: name}, scaledObject); err != nil {
+				if apiErrors.IsNotFound(err) {
+					return nil, nil
+				}
+				return nil, err
+			}
+			return scaledObject, nil
+		}
+		for i := range r.scaledObjects.Items {
+			if r.scaledObjects.Items[i].Name == name {
+				return &r.scaledObjects.Items[i], nil
+			}
+		}
+		return nil, nil
+	}
+
+	if r.scaledObjects == nil {
+		scaledObjects := &kedav1alpha1.ScaledObjectList{}
+		if err := r.p.client.List(context.TODO(), scaledObjects, client.InNamespace(r.namespace)); err != nil {
+			return nil, err
+		}
+		r.scaledObjects = scaledObjects
+	}
+	if len(r.scaledObjects.Items) == 0 {
+		return nil, nil
+	}
+
+	var visited []resolvedObject
+	scaledObject, err := r.walkOwners(groupResource, name, &visited)
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range visited {
+		r.resolved[object] = scaledObject
+	}
+	return scaledObject, nil
+}
+
+// walkOwners follows the controllers of the object up to the scale target of a ScaledObject,
+// the objects without a previous result are added to visited
+func (r *scaledObjectResolver) walkOwners(groupResource schema.GroupResource, name string, visited *[]resolvedObject) (*kedav1alpha1.ScaledObject, error) {
+	for depth := 0; depth <= maxOwnerDepth; depth++ {
+		key := resolvedObject{groupResource: groupResource, name: name}
+		if scaledObject, ok := r.resolved[key]; ok {
+			return scaledObject, nil
+		}
+		*visited = append(*visited, key)
+
+		for i, scaledObject := range r.scaledObjects.Items {
+			gvkr := scaledObject.Status.ScaleTargetGVKR
+			if gvkr != nil && gvkr.GroupResource() == groupResource && scaledObject.Spec.ScaleTargetRef != nil && scaledObject.Spec.ScaleTargetRef.Name == name {
+				return &r.scaledObjects.Items[i], nil
+			}
+		}
+
+		if depth == maxOwnerDepth {
+			break
+		}
+
+		// continue with the controller of the object
+		gvk, err := r.p.mapper.KindFor(groupResource.WithVersion(""))
+		if err != nil {
+			return nil, err
+		}
+		object := &unstructured.Unstructured{}
+		object.SetGroupVersionKind(gvk)
+		if err := r.p.client.Get(context.TODO(), types.NamespacedName{Namespace: r.namespace, Name: name}, object); err != nil {
+			if apiErrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+
+		owner := metav1.GetControllerOf(object)
+		if owner == nil {
+			return nil, nil
+		}
+		ownerGroupVersion, err := schema.ParseGroupVersion(owner.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing apiVersion of the owner of %s %s: %s", groupResource.String(), name, err)
+		}
+		mapping, err := r.p.mapper.RESTMapping(ownerGroupVersion.WithKind(owner.Kind).GroupKind(), ownerGroupVersion.Version)
+		if err != nil {
+			return nil, err
+		}
+		groupResource = mapping.Resource.GroupResource()
+		name = owner.Name
+	}
+
+	return nil, nil
+}
+
+// hasExternalMetric returns true if the metric is one of the metrics of the ScaledObject
+func hasExternalMetric(scaledObject *kedav1alpha1.ScaledObject, metricName string) bool {
+	for _, name := range scaledObject.Status.ExternalMetricNames {
+		if name == metricName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/kubernetes-incubator/custom-metrics-apiserver/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/metricsservice"
+	"github.com/kedacore/keda/pkg/metricsservice/api"
+	"github.com/kedacore/keda/pkg/metricsservice/metricsservicetest"
+)
+
+const testNamespace = "test"
+
+func newTestProvider(t *testing.T, objects ...runtime.Object) *KedaProvider {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{})
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Pod"), meta.RESTScopeNamespace)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"), meta.RESTScopeNamespace)
+	mapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+	mapper.Add(kedav1alpha1.GroupVersion.WithKind("ScaledObject"), meta.RESTScopeNamespace)
+
+	logger = logf.Log.WithName("test")
+	return &KedaProvider{
+		client:           fake.NewFakeClientWithScheme(scheme, objects...),
+		mapper:           mapper,
+		watchedNamespace: testNamespace,
+	}
+}
+
+func newControllerRef(apiVersion, kind, name string) []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{APIVersion: apiVersion, Kind: kind, Name: name, Controller: &isController}}
+}
+
+func newTestObjects() []runtime.Object {
+	return []runtime.Object{
+		&kedav1alpha1.ScaledObject{
+			ObjectMeta: metav1.ObjectMeta{Name: "so", Namespace: testNamespace},
+			Spec:       kedav1alpha1.ScaledObjectSpec{ScaleTargetRef: &kedav1alpha1.ScaleTarget{Name: "deployment"}},
+			Status: kedav1alpha1.ScaledObjectStatus{
+				ScaleTargetGVKR:     &kedav1alpha1.GroupVersionKindResource{Group: "apps", Version: "v1", Kind: "Deployment", Resource: "deployments"},
+				ExternalMetricNames: []string{"s0-metric"},
+			},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: testNamespace}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "replicaset", Namespace: testNamespace, OwnerReferences: newControllerRef("apps/v1", "Deployment", "deployment")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: testNamespace, Labels: map[string]string{"app": "test"}, OwnerReferences: newControllerRef("apps/v1", "ReplicaSet", "replicaset")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-2", Namespace: testNamespace, Labels: map[string]string{"app": "test"}, OwnerReferences: newControllerRef("apps/v1", "ReplicaSet", "replicaset")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged-pod", Namespace: testNamespace, Labels: map[string]string{"app": "test"}}},
+	}
+}
+
+func TestFindScaledObjectForObject(t *testing.T) {
+	p := newTestProvider(t, newTestObjects()...)
+
+	for _, testData := range []struct {
+		groupResource schema.GroupResource
+		name          string
+		found         bool
+	}{
+		{scaledObjectGroupResource, "so", true},
+		{scaledObjectGroupResource, "other", false},
+		{schema.GroupResource{Group: "apps", Resource: "deployments"}, "deployment", true},
+		{schema.GroupResource{Group: "apps", Resource: "replicasets"}, "replicaset", true},
+		{podGroupResource, "pod", true},
+		{podGroupResource, "unmanaged-pod", false},
+		{podGroupResource, "not-existing-pod", false},
+	} {
+		scaledObject, err := p.newScaledObjectResolver(testNamespace).find(testData.groupResource, testData.name)
+		assert.NoError(t, err)
+		if testData.found {
+			if assert.NotNil(t, scaledObject, "%s %s", testData.groupResource, testData.name) {
+				assert.Equal(t, "so", scaledObject.Name)
+			}
+		} else {
+			assert.Nil(t, scaledObject, "%s %s", testData.groupResource, testData.name)
+		}
+	}
+}
+
+func TestListAllMetrics(t *testing.T) {
+	p := newTestProvider(t, newTestObjects()...)
+
+	var groupResources []string
+	for _, info := range p.ListAllMetrics() {
+		assert.Equal(t, "s0-metric", info.Metric)
+		assert.True(t, info.Namespaced)
+		groupResources = append(groupResources, info.GroupResource.String())
+	}
+	assert.ElementsMatch(t, []string{"scaledobjects.keda.sh", "pods", "deployments.apps"}, groupResources)
+}
+
+type fakeMetricsService struct {
+	requests []*api.ScaledObjectRef
+}
+
+func (s *fakeMetricsService) GetMetrics(ctx context.Context, in *api.ScaledObjectRef) (*api.Response, error) {
+	s.requests = append(s.requests, in)
+	return &api.Response{Metrics: []*api.MetricValue{{MetricName: in.MetricName, Value: "10", ScalerName: "fakeScaler"}}}, nil
+}
+
+func TestGetMetricBySelector(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	certDir := t.TempDir()
+	if err := metricsservicetest.WriteCertificates(certDir); err != nil {
+		t.Fatal(err)
+	}
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(certDir, "tls.crt"), filepath.Join(certDir, "tls.key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}})))
+	metricsService := &fakeMetricsService{}
+	api.RegisterMetricsServiceServer(server, metricsService)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	p := newTestProvider(t, newTestObjects()...)
+	p.grpcClient, err = metricsservice.NewGrpcClient(listener.Addr().String(), certDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.grpcClient.Close()
+
+	selector, err := labels.Parse("app=test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := provider.CustomMetricInfo{GroupResource: podGroupResource, Namespaced: true, Metric: "s0-metric"}
+	values, err := p.GetMetricBySelector(testNamespace, selector, info, labels.Everything())
+	assert.NoError(t, err)
+
+	// the value of the ScaledObject is split between its pods, the unmanaged pod is skipped
+	var names []string
+	for _, value := range values.Items {
+		names = append(names, value.DescribedObject.Name)
+		assert.Equal(t, "5", value.Value.String())
+	}
+	assert.ElementsMatch(t, []string{"pod", "pod-2"}, names)
+	assert.Len(t, metricsService.requests, 1)
+}
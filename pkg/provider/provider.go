@@ -2,29 +2,30 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
 	prommetrics "github.com/kedacore/keda/pkg/metrics"
-	"github.com/kedacore/keda/pkg/scaling"
+	"github.com/kedacore/keda/pkg/metricsservice"
 
 	"github.com/go-logr/logr"
 	"github.com/kubernetes-incubator/custom-metrics-apiserver/pkg/provider"
-	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/metrics/pkg/apis/custom_metrics"
 	"k8s.io/metrics/pkg/apis/external_metrics"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// KedaProvider implements External Metrics Provider
+// KedaProvider implements External and Custom Metrics Provider
 type KedaProvider struct {
 	client           client.Client
 	values           map[provider.CustomMetricInfo]int64
 	externalMetrics  []externalMetric
-	scaleHandler     scaling.ScaleHandler
+	grpcClient       *metricsservice.GrpcClient
+	mapper           meta.RESTMapper
 	watchedNamespace string
 }
 
@@ -34,12 +35,13 @@ var logger logr.Logger
 var metricsServer prommetrics.PrometheusMetricServer
 
 // NewProvider returns an instance of KedaProvider
-func NewProvider(adapterLogger logr.Logger, scaleHandler scaling.ScaleHandler, client client.Client, watchedNamespace string) provider.MetricsProvider {
+func NewProvider(adapterLogger logr.Logger, grpcClient *metricsservice.GrpcClient, client client.Client, mapper meta.RESTMapper, watchedNamespace string) provider.MetricsProvider {
 	provider := &KedaProvider{
 		values:           make(map[provider.CustomMetricInfo]int64),
 		externalMetrics:  make([]externalMetric, 2, 10),
 		client:           client,
-		scaleHandler:     scaleHandler,
+		grpcClient:       grpcClient,
+		mapper:           mapper,
 		watchedNamespace: watchedNamespace,
 	}
 	logger = adapterLogger.WithName("provider")
@@ -76,39 +78,9 @@ func (p *KedaProvider) GetExternalMetric(namespace string, metricSelector labels
 	}
 
 	scaledObject := &scaledObjects.Items[0]
-	matchingMetrics := []external_metrics.ExternalMetricValue{}
-	scalers, err := p.scaleHandler.GetScalers(scaledObject)
-	metricsServer.RecordScalerObjectError(scaledObject.Namespace, scaledObject.Name, err)
+	matchingMetrics, err := p.getScaledObjectMetrics(scaledObject, info.Metric, metricSelector)
 	if err != nil {
-		return nil, fmt.Errorf("error when getting scalers %s", err)
-	}
-
-	for scalerIndex, scaler := range scalers {
-		metricSpecs := scaler.GetMetricSpecForScaling()
-		scalerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
-
-		for _, metricSpec := range metricSpecs {
-			//skip cpu/memory resource scaler
-			if metricSpec.External == nil {
-				continue
-			}
-			// Filter only the desired metric
-			if strings.EqualFold(metricSpec.External.Metric.Name, info.Metric) {
-				metrics, err := scaler.GetMetrics(context.TODO(), info.Metric, metricSelector)
-				if err != nil {
-					logger.Error(err, "error getting metric for scaler", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scaler", scaler)
-				} else {
-					for _, metric := range metrics {
-						metricValue, _ := metric.Value.AsInt64()
-						metricsServer.RecordHPAScalerMetric(namespace, scaledObject.Name, scalerName, scalerIndex, metric.MetricName, metricValue)
-					}
-					matchingMetrics = append(matchingMetrics, metrics...)
-				}
-				metricsServer.RecordHPAScalerError(namespace, scaledObject.Name, scalerName, scalerIndex, info.Metric, err)
-			}
-		}
-
-		scaler.Close()
+		return nil, err
 	}
 
 	if len(matchingMetrics) <= 0 {
@@ -120,6 +92,45 @@ func (p *KedaProvider) GetExternalMetric(namespace string, metricSelector labels
 	}, nil
 }
 
+// getScaledObjectMetrics requests the metric of the ScaledObject from KEDA Operator, which computes it by the scalers
+func (p *KedaProvider) getScaledObjectMetrics(scaledObject *kedav1alpha1.ScaledObject, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	matchingMetrics := []external_metrics.ExternalMetricValue{}
+
+	// metrics are computed by the operator, which holds the scalers, and served over gRPC
+	response, err := p.grpcClient.GetMetrics(context.TODO(), scaledObject.Name, scaledObject.Namespace, metricName, metricSelector)
+	metricsServer.RecordScalerObjectError(scaledObject.Namespace, scaledObject.Name, err)
+	if err != nil {
+		return nil, fmt.Errorf("error when getting metrics from KEDA Operator %s", err)
+	}
+
+	for _, scalerError := range response.Errors {
+		err := errors.New(scalerError.Error)
+		logger.Error(err, "error getting metric for scaler", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scaler", scalerError.ScalerName)
+		metricsServer.RecordHPAScalerError(scaledObject.Namespace, scaledObject.Name, scalerError.ScalerName, int(scalerError.ScalerIndex), scalerError.MetricName, err)
+	}
+
+	for _, metric := range response.Metrics {
+		value, err := resource.ParseQuantity(metric.Value)
+		if err != nil {
+			logger.Error(err, "error parsing metric value", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scaler", metric.ScalerName)
+			continue
+		}
+
+		metricValue, _ := value.AsInt64()
+		metricsServer.RecordHPAScalerMetric(scaledObject.Namespace, scaledObject.Name, metric.ScalerName, int(metric.ScalerIndex), metric.MetricName, metricValue)
+		metricsServer.RecordHPAScalerError(scaledObject.Namespace, scaledObject.Name, metric.ScalerName, int(metric.ScalerIndex), metric.MetricName, nil)
+
+		matchingMetrics = append(matchingMetrics, external_metrics.ExternalMetricValue{
+			MetricName:   metric.MetricName,
+			MetricLabels: metric.MetricLabels,
+			Timestamp:    metav1.Unix(metric.Timestamp, 0),
+			Value:        value,
+		})
+	}
+
+	return matchingMetrics, nil
+}
+
 // ListAllExternalMetrics returns the supported external metrics for this provider
 func (p *KedaProvider) ListAllExternalMetrics() []provider.ExternalMetricInfo {
 	externalMetricsInfo := []provider.ExternalMetricInfo{}
@@ -143,26 +154,3 @@ func (p *KedaProvider) ListAllExternalMetrics() []provider.ExternalMetricInfo {
 	}
 	return externalMetricsInfo
 }
-
-// GetMetricByName fetches a particular metric for a particular object.
-// The namespace will be empty if the metric is root-scoped.
-func (p *KedaProvider) GetMetricByName(name types.NamespacedName, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValue, error) {
-	// not implemented yet
-	return nil, apiErrors.NewServiceUnavailable("not implemented yet")
-}
-
-// GetMetricBySelector fetches a particular metric for a set of objects matching
-// the given label selector.  The namespace will be empty if the metric is root-scoped.
-func (p *KedaProvider) GetMetricBySelector(namespace string, selector labels.Selector, info provider.CustomMetricInfo, metricSelector labels.Selector) (*custom_metrics.MetricValueList, error) {
-	logger.V(0).Info("Received request for custom metric", "groupresource", info.GroupResource.String(), "namespace", namespace, "metric name", info.Metric, "selector", selector.String())
-	return nil, apiErrors.NewServiceUnavailable("not implemented yet")
-}
-
-// ListAllMetrics provides a list of all available metrics at
-// the current time.  Note that this is not allowed to return
-// an error, so it is recommended that implementors cache and
-// periodically update this list, instead of querying every time.
-func (p *KedaProvider) ListAllMetrics() []provider.CustomMetricInfo {
-	// not implemented yet
-	return []provider.CustomMetricInfo{}
-}
@@ -26,14 +26,15 @@ type artemisScaler struct {
 
 //revive:disable:var-naming breaking change on restApiTemplate, wouldn't bring any benefit to users
 type artemisMetadata struct {
-	managementEndpoint string
-	queueName          string
-	brokerName         string
-	brokerAddress      string
-	username           string
-	password           string
-	restAPITemplate    string
-	queueLength        int
+	managementEndpoint    string
+	queueName             string
+	brokerName            string
+	brokerAddress         string
+	username              string
+	password              string
+	restAPITemplate       string
+	queueLength           int
+	activationQueueLength float64
 }
 
 //revive:enable:var-naming
@@ -136,6 +137,13 @@ func parseArtemisMetadata(config *ScalerConfig) (*artemisMetadata, error) {
 	if meta.password == "" {
 		return nil, fmt.Errorf("password cannot be empty")
 	}
+
+	activation, err := config.GetActivationThreshold("activationQueueLength", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationQueueLength = activation
+
 	return &meta, nil
 }
 
@@ -147,7 +155,7 @@ func (s *artemisScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return messages > 0, nil
+	return float64(messages) > s.metadata.activationQueueLength, nil
 }
 
 func (s *artemisScaler) getMonitoringEndpoint() string {
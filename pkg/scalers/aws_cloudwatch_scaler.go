@@ -3,13 +3,13 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -18,6 +18,7 @@ import (
 	"k8s.io/metrics/pkg/apis/external_metrics"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
 
 	kedautil "github.com/kedacore/keda/pkg/util"
 )
@@ -26,8 +27,14 @@ const (
 	defaultMetricCollectionTime = 300
 	defaultMetricStat           = "Average"
 	defaultMetricStatPeriod     = 300
+
+	// awsCloudwatchQueryID is the id of the single metric query or the expression returning the metric value
+	awsCloudwatchQueryID           = "c1"
+	awsCloudwatchExpressionQueryID = "expression"
 )
 
+var awsCloudwatchQueryIDRegex = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+
 type awsCloudwatchScaler struct {
 	metadata *awsCloudwatchMetadata
 }
@@ -35,21 +42,44 @@ type awsCloudwatchScaler struct {
 type awsCloudwatchMetadata struct {
 	namespace      string
 	metricsName    string
-	dimensionName  string
-	dimensionValue string
+	dimensionName  []string
+	dimensionValue []string
+
+	// expression is a metric math expression, which may refer to the ids of metricQueries
+	expression    string
+	metricQueries []awsCloudwatchMetricQuery
+	// metricQueriesSpec is the raw metricQueries metadata, part of the metric name in expression mode
+	metricQueriesSpec string
 
 	targetMetricValue float64
 	minMetricValue    float64
 
+	// activationTargetMetricValue defaults to minMetricValue
+	activationTargetMetricValue float64
+
 	metricCollectionTime int64
 	metricStat           string
 	metricStatPeriod     int64
 
-	awsRegion string
+	awsRegion   string
+	awsEndpoint string
 
 	awsAuthorization awsAuthorizationMetadata
 }
 
+// awsCloudwatchMetricQuery is a metric referred to by its id in the expression
+type awsCloudwatchMetricQuery struct {
+	ID               string `json:"id"`
+	Namespace        string `json:"namespace"`
+	MetricName       string `json:"metricName"`
+	DimensionName    string `json:"dimensionName"`
+	DimensionValue   string `json:"dimensionValue"`
+	MetricStat       string `json:"metricStat"`
+	MetricStatPeriod int64  `json:"metricStatPeriod"`
+
+	dimensions []*cloudwatch.Dimension
+}
+
 var cloudwatchLog = logf.Log.WithName("aws_cloudwatch_scaler")
 
 // NewAwsCloudwatchScaler creates a new awsCloudwatchScaler
@@ -70,28 +100,17 @@ func parseAwsCloudwatchMetadata(config *ScalerConfig) (*awsCloudwatchMetadata, e
 	meta.metricStat = defaultMetricStat
 	meta.metricStatPeriod = defaultMetricStatPeriod
 
-	if val, ok := config.TriggerMetadata["namespace"]; ok && val != "" {
-		meta.namespace = val
-	} else {
-		return nil, fmt.Errorf("namespace not given")
-	}
-
-	if val, ok := config.TriggerMetadata["metricName"]; ok && val != "" {
-		meta.metricsName = val
-	} else {
-		return nil, fmt.Errorf("metric name not given")
-	}
-
-	if val, ok := config.TriggerMetadata["dimensionName"]; ok && val != "" {
-		meta.dimensionName = val
-	} else {
-		return nil, fmt.Errorf("dimension name not given")
-	}
-
-	if val, ok := config.TriggerMetadata["dimensionValue"]; ok && val != "" {
-		meta.dimensionValue = val
+	if val, ok := config.TriggerMetadata["expression"]; ok && val != "" {
+		meta.expression = val
+		for _, key := range []string{"namespace", "metricName", "dimensionName", "dimensionValue"} {
+			if config.TriggerMetadata[key] != "" {
+				return nil, fmt.Errorf("%s must not be used together with expression, use metricQueries instead", key)
+			}
+		}
 	} else {
-		return nil, fmt.Errorf("dimension value not given")
+		if err := parseAwsCloudwatchMetric(config.TriggerMetadata, &meta); err != nil {
+			return nil, err
+		}
 	}
 
 	if val, ok := config.TriggerMetadata["targetMetricValue"]; ok && val != "" {
@@ -116,6 +135,12 @@ func parseAwsCloudwatchMetadata(config *ScalerConfig) (*awsCloudwatchMetadata, e
 		return nil, fmt.Errorf("min metric value not given")
 	}
 
+	activation, err := config.GetActivationThreshold("activationTargetMetricValue", meta.minMetricValue)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationTargetMetricValue = activation
+
 	if val, ok := config.TriggerMetadata["metricCollectionTime"]; ok && val != "" {
 		metricCollectionTime, err := strconv.Atoi(val)
 		if err != nil {
@@ -138,13 +163,24 @@ func parseAwsCloudwatchMetadata(config *ScalerConfig) (*awsCloudwatchMetadata, e
 		}
 	}
 
+	if val, ok := config.TriggerMetadata["metricQueries"]; ok && val != "" {
+		if meta.expression == "" {
+			return nil, fmt.Errorf("metricQueries must be used together with expression")
+		}
+		if err := parseAwsCloudwatchMetricQueries(val, &meta); err != nil {
+			return nil, err
+		}
+	}
+
 	if val, ok := config.TriggerMetadata["awsRegion"]; ok && val != "" {
 		meta.awsRegion = val
 	} else {
 		return nil, fmt.Errorf("no awsRegion given")
 	}
 
-	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	meta.awsEndpoint = config.TriggerMetadata["awsEndpoint"]
+
+	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
 	if err != nil {
 		return nil, err
 	}
@@ -154,6 +190,105 @@ func parseAwsCloudwatchMetadata(config *ScalerConfig) (*awsCloudwatchMetadata, e
 	return &meta, nil
 }
 
+// parseAwsCloudwatchMetric parses the single metric queried if no expression is given
+func parseAwsCloudwatchMetric(metadata map[string]string, meta *awsCloudwatchMetadata) error {
+	if val, ok := metadata["namespace"]; ok && val != "" {
+		meta.namespace = val
+	} else {
+		return fmt.Errorf("namespace not given")
+	}
+
+	if val, ok := metadata["metricName"]; ok && val != "" {
+		meta.metricsName = val
+	} else {
+		return fmt.Errorf("metric name not given")
+	}
+
+	if val, ok := metadata["dimensionName"]; ok && val != "" {
+		meta.dimensionName = splitAwsCloudwatchDimensions(val)
+	} else {
+		return fmt.Errorf("dimension name not given")
+	}
+
+	if val, ok := metadata["dimensionValue"]; ok && val != "" {
+		meta.dimensionValue = splitAwsCloudwatchDimensions(val)
+	} else {
+		return fmt.Errorf("dimension value not given")
+	}
+
+	_, err := getAwsCloudwatchDimensions(meta.dimensionName, meta.dimensionValue)
+	return err
+}
+
+// parseAwsCloudwatchMetricQueries parses the list of metrics referred to by the expression,
+// metricStat and metricStatPeriod of the trigger are the defaults of the metric queries
+func parseAwsCloudwatchMetricQueries(value string, meta *awsCloudwatchMetadata) error {
+	if err := yaml.UnmarshalStrict([]byte(value), &meta.metricQueries); err != nil {
+		return fmt.Errorf("error parsing metricQueries: %s", err)
+	}
+	meta.metricQueriesSpec = value
+
+	ids := map[string]bool{}
+	for i := range meta.metricQueries {
+		query := &meta.metricQueries[i]
+		if !awsCloudwatchQueryIDRegex.MatchString(query.ID) || query.ID == awsCloudwatchExpressionQueryID {
+			return fmt.Errorf("metric query #%d: id must start with a lowercase letter followed by letters, digits or underscores and must not be %s, but is %s", i, awsCloudwatchExpressionQueryID, query.ID)
+		}
+		if ids[query.ID] {
+			return fmt.Errorf("metric query #%d: id %s is used more than once", i, query.ID)
+		}
+		ids[query.ID] = true
+
+		if query.Namespace == "" || query.MetricName == "" {
+			return fmt.Errorf("metric query %s: namespace and metricName must be given", query.ID)
+		}
+		if query.MetricStat == "" {
+			query.MetricStat = meta.metricStat
+		}
+		if query.MetricStatPeriod == 0 {
+			query.MetricStatPeriod = meta.metricStatPeriod
+		}
+
+		if query.DimensionName != "" || query.DimensionValue != "" {
+			dimensions, err := getAwsCloudwatchDimensions(splitAwsCloudwatchDimensions(query.DimensionName), splitAwsCloudwatchDimensions(query.DimensionValue))
+			if err != nil {
+				return fmt.Errorf("metric query %s: %s", query.ID, err)
+			}
+			query.dimensions = dimensions
+		}
+	}
+	return nil
+}
+
+// splitAwsCloudwatchDimensions splits semicolon-separated dimension names or values, empty entries are kept
+// so that they are reported instead of silently shifting the pairs of names and values
+func splitAwsCloudwatchDimensions(value string) []string {
+	parts := strings.Split(value, ";")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// getAwsCloudwatchDimensions pairs the semicolon-separated dimension names and values
+func getAwsCloudwatchDimensions(names []string, values []string) ([]*cloudwatch.Dimension, error) {
+	if len(names) != len(values) {
+		return nil, fmt.Errorf("%d dimension names but %d dimension values given", len(names), len(values))
+	}
+
+	dimensions := make([]*cloudwatch.Dimension, 0, len(names))
+	for i := range names {
+		if names[i] == "" || values[i] == "" {
+			return nil, fmt.Errorf("dimension names and values must not be empty")
+		}
+		dimensions = append(dimensions, &cloudwatch.Dimension{
+			Name:  aws.String(names[i]),
+			Value: aws.String(values[i]),
+		})
+	}
+	return dimensions, nil
+}
+
 func (c *awsCloudwatchScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	metricValue, err := c.GetCloudwatchMetrics()
 
@@ -164,18 +299,31 @@ func (c *awsCloudwatchScaler) GetMetrics(ctx context.Context, metricName string,
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(metricValue), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(metricValue*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
+// getMetricName returns a name made from the namespace and the dimensions of the metric,
+// and from a hash of the expression and its metric queries in expression mode
+func (c *awsCloudwatchScaler) getMetricName() string {
+	if c.metadata.expression == "" {
+		return kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s-%s", "aws-cloudwatch", c.metadata.namespace, strings.Join(c.metadata.dimensionName, "-"), strings.Join(c.metadata.dimensionValue, "-")))
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(c.metadata.expression))
+	_, _ = hash.Write([]byte(c.metadata.metricQueriesSpec))
+	return fmt.Sprintf("%s-%x", "aws-cloudwatch-expression", hash.Sum32())
+}
+
 func (c *awsCloudwatchScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
-	targetMetricValue := resource.NewQuantity(int64(c.metadata.targetMetricValue), resource.DecimalSI)
+	targetMetricValue := resource.NewMilliQuantity(int64(c.metadata.targetMetricValue*1000), resource.DecimalSI)
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s-%s", "aws-cloudwatch", c.metadata.namespace, c.metadata.dimensionName, c.metadata.dimensionValue)),
+			Name: c.getMetricName(),
 		},
 		Target: v2beta2.MetricTarget{
 			Type:         v2beta2.AverageValueMetricType,
@@ -193,51 +341,25 @@ func (c *awsCloudwatchScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return val > c.metadata.minMetricValue, nil
+	return val > c.metadata.activationTargetMetricValue, nil
 }
 
 func (c *awsCloudwatchScaler) Close() error {
 	return nil
 }
 
-func (c *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(c.metadata.awsRegion),
-	}))
-
-	var cloudwatchClient *cloudwatch.CloudWatch
-	if c.metadata.awsAuthorization.podIdentityOwner {
-		creds := credentials.NewStaticCredentials(c.metadata.awsAuthorization.awsAccessKeyID, c.metadata.awsAuthorization.awsSecretAccessKey, "")
-
-		if c.metadata.awsAuthorization.awsRoleArn != "" {
-			creds = stscreds.NewCredentials(sess, c.metadata.awsAuthorization.awsRoleArn)
-		}
-
-		cloudwatchClient = cloudwatch.New(sess, &aws.Config{
-			Region:      aws.String(c.metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		cloudwatchClient = cloudwatch.New(sess, &aws.Config{
-			Region: aws.String(c.metadata.awsRegion),
-		})
-	}
-
-	input := cloudwatch.GetMetricDataInput{
-		StartTime: aws.Time(time.Now().Add(time.Second * -1 * time.Duration(c.metadata.metricCollectionTime))),
-		EndTime:   aws.Time(time.Now()),
-		MetricDataQueries: []*cloudwatch.MetricDataQuery{
+// getMetricDataQueries returns the single metric query, or the expression returning the metric value
+// together with the metric queries it refers to
+func (c *awsCloudwatchScaler) getMetricDataQueries() []*cloudwatch.MetricDataQuery {
+	if c.metadata.expression == "" {
+		dimensions, _ := getAwsCloudwatchDimensions(c.metadata.dimensionName, c.metadata.dimensionValue)
+		return []*cloudwatch.MetricDataQuery{
 			{
-				Id: aws.String("c1"),
+				Id: aws.String(awsCloudwatchQueryID),
 				MetricStat: &cloudwatch.MetricStat{
 					Metric: &cloudwatch.Metric{
-						Namespace: aws.String(c.metadata.namespace),
-						Dimensions: []*cloudwatch.Dimension{
-							{
-								Name:  aws.String(c.metadata.dimensionName),
-								Value: aws.String(c.metadata.dimensionValue),
-							},
-						},
+						Namespace:  aws.String(c.metadata.namespace),
+						Dimensions: dimensions,
 						MetricName: aws.String(c.metadata.metricsName),
 					},
 					Period: aws.Int64(c.metadata.metricStatPeriod),
@@ -245,8 +367,43 @@ func (c *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
 				},
 				ReturnData: aws.Bool(true),
 			},
+		}
+	}
+
+	queries := []*cloudwatch.MetricDataQuery{
+		{
+			Id:         aws.String(awsCloudwatchExpressionQueryID),
+			Expression: aws.String(c.metadata.expression),
+			Period:     aws.Int64(c.metadata.metricStatPeriod),
+			ReturnData: aws.Bool(true),
 		},
 	}
+	for _, query := range c.metadata.metricQueries {
+		queries = append(queries, &cloudwatch.MetricDataQuery{
+			Id: aws.String(query.ID),
+			MetricStat: &cloudwatch.MetricStat{
+				Metric: &cloudwatch.Metric{
+					Namespace:  aws.String(query.Namespace),
+					Dimensions: query.dimensions,
+					MetricName: aws.String(query.MetricName),
+				},
+				Period: aws.Int64(query.MetricStatPeriod),
+				Stat:   aws.String(query.MetricStat),
+			},
+			ReturnData: aws.Bool(false),
+		})
+	}
+	return queries
+}
+
+func (c *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
+	cloudwatchClient := cloudwatch.New(getAwsConfig(c.metadata.awsRegion, c.metadata.awsEndpoint, c.metadata.awsAuthorization))
+
+	input := cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(time.Now().Add(time.Second * -1 * time.Duration(c.metadata.metricCollectionTime))),
+		EndTime:           aws.Time(time.Now()),
+		MetricDataQueries: c.getMetricDataQueries(),
+	}
 
 	output, err := cloudwatchClient.GetMetricData(&input)
 
@@ -256,12 +413,16 @@ func (c *awsCloudwatchScaler) GetCloudwatchMetrics() (float64, error) {
 	}
 
 	cloudwatchLog.V(1).Info("Received Metric Data", "data", output)
-	var metricValue float64
-	if output.MetricDataResults[0].Values != nil {
-		metricValue = *output.MetricDataResults[0].Values[0]
-	} else {
-		return -1, fmt.Errorf("metric data not received")
+	for _, result := range output.MetricDataResults {
+		if aws.StringValue(result.Id) != *input.MetricDataQueries[0].Id {
+			continue
+		}
+		if len(result.Values) == 0 {
+			break
+		}
+		// values are ordered by timestamp descending, the first is the latest
+		return aws.Float64Value(result.Values[0]), nil
 	}
 
-	return metricValue, nil
+	return -1, fmt.Errorf("metric data not received")
 }
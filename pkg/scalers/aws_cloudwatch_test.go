@@ -1,6 +1,9 @@
 package scalers
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -164,10 +167,127 @@ var testAWSCloudwatchMetadata = []parseAWSCloudwatchMetadataTestData{
 		map[string]string{},
 		false,
 		"with AWS Role assigned on KEDA operator itself"},
+	{map[string]string{
+		"namespace":         "AWS/ApplicationELB",
+		"dimensionName":     "LoadBalancer; TargetGroup",
+		"dimensionValue":    "app/keda/1234;targetgroup/keda/5678",
+		"metricName":        "RequestCountPerTarget",
+		"targetMetricValue": "100",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1",
+		"awsEndpoint":       "http://localhost:4566"},
+		testAWSAuthentication,
+		false,
+		"multiple dimensions and custom endpoint"},
+	{map[string]string{
+		"namespace":         "AWS/ApplicationELB",
+		"dimensionName":     "LoadBalancer;TargetGroup",
+		"dimensionValue":    "app/keda/1234",
+		"metricName":        "RequestCountPerTarget",
+		"targetMetricValue": "100",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"more dimension names than values"},
+	{map[string]string{
+		"namespace":         "AWS/ApplicationELB",
+		"dimensionName":     "LoadBalancer;",
+		"dimensionValue":    "app/keda/1234;targetgroup/keda/5678",
+		"metricName":        "RequestCountPerTarget",
+		"targetMetricValue": "100",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"empty dimension name"},
+	{map[string]string{
+		"expression":        "m1/m2",
+		"metricQueries":     testAWSCloudwatchMetricQueries,
+		"targetMetricValue": "0.5",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		false,
+		"expression with metric queries"},
+	{map[string]string{
+		"expression":        "SUM(SEARCH('{AWS/SQS,QueueName} MetricName=\"ApproximateNumberOfMessagesVisible\"', 'Average', 300))",
+		"targetMetricValue": "10",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		false,
+		"search expression without metric queries"},
+	{map[string]string{
+		"expression":        "m1/m2",
+		"metricQueries":     testAWSCloudwatchMetricQueries,
+		"namespace":         "AWS/SQS",
+		"targetMetricValue": "0.5",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"expression together with namespace"},
+	{map[string]string{
+		"namespace":         "AWS/SQS",
+		"dimensionName":     "QueueName",
+		"dimensionValue":    "keda",
+		"metricName":        "ApproximateNumberOfMessagesVisible",
+		"metricQueries":     testAWSCloudwatchMetricQueries,
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"metric queries without expression"},
+	{map[string]string{
+		"expression":        "M1",
+		"metricQueries":     `[{"id": "M1", "namespace": "AWS/SQS", "metricName": "NumberOfMessagesSent"}]`,
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"invalid metric query id"},
+	{map[string]string{
+		"expression":        "m1",
+		"metricQueries":     `[{"id": "m1", "namespace": "AWS/SQS", "metricName": "NumberOfMessagesSent"}, {"id": "m1", "namespace": "AWS/SQS", "metricName": "NumberOfMessagesDeleted"}]`,
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"duplicate metric query id"},
+	{map[string]string{
+		"expression":        "m1",
+		"metricQueries":     `[{"id": "m1", "metricName": "NumberOfMessagesSent"}]`,
+		"targetMetricValue": "2",
+		"minMetricValue":    "0",
+		"awsRegion":         "eu-west-1"},
+		testAWSAuthentication,
+		true,
+		"metric query without namespace"},
 }
 
+const testAWSCloudwatchMetricQueries = `
+- id: m1
+  namespace: AWS/SQS
+  metricName: ApproximateNumberOfMessagesVisible
+  dimensionName: QueueName
+  dimensionValue: keda
+- id: m2
+  namespace: AWS/ECS
+  metricName: CPUUtilization
+  dimensionName: ClusterName;ServiceName
+  dimensionValue: keda;worker
+  metricStat: Maximum
+  metricStatPeriod: 60
+`
+
 var awsCloudwatchMetricIdentifiers = []awsCloudwatchMetricIdentifier{
 	{&testAWSCloudwatchMetadata[1], "aws-cloudwatch-AWS-SQS-QueueName-keda"},
+	{&testAWSCloudwatchMetadata[11], "aws-cloudwatch-AWS-ApplicationELB-LoadBalancer-TargetGroup-app-keda-1234-targetgroup-keda-5678"},
+	{&testAWSCloudwatchMetadata[14], "aws-cloudwatch-expression-78093e80"},
 }
 
 func TestCloudwatchParseMetadata(t *testing.T) {
@@ -197,3 +317,54 @@ func TestAWSCloudwatchGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestAWSCloudwatchGetMetricsFromEndpoint(t *testing.T) {
+	var form map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error("Could not parse request:", err)
+		}
+		form = r.Form
+		fmt.Fprintf(w, `<GetMetricDataResponse><GetMetricDataResult><MetricDataResults>
+<member><Id>%s</Id><StatusCode>Complete</StatusCode><Values><member>2.5</member><member>1</member></Values></member>
+</MetricDataResults></GetMetricDataResult><ResponseMetadata><RequestId>test</RequestId></ResponseMetadata></GetMetricDataResponse>`, r.Form.Get("MetricDataQueries.member.1.Id"))
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{}
+	for k, v := range testAWSCloudwatchMetadata[14].metadata {
+		metadata[k] = v
+	}
+	metadata["awsEndpoint"] = server.URL
+	meta, err := parseAwsCloudwatchMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: testAWSAuthentication})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := awsCloudwatchScaler{meta}
+
+	value, err := scaler.GetCloudwatchMetrics()
+	if err != nil {
+		t.Fatal("Expected success but got error:", err)
+	}
+	if value != 2.5 {
+		t.Errorf("Expected latest value 2.5 but got %f", value)
+	}
+
+	expected := map[string]string{
+		"MetricDataQueries.member.1.Id":                                          "expression",
+		"MetricDataQueries.member.1.Expression":                                  "m1/m2",
+		"MetricDataQueries.member.1.ReturnData":                                  "true",
+		"MetricDataQueries.member.2.Id":                                          "m1",
+		"MetricDataQueries.member.2.ReturnData":                                  "false",
+		"MetricDataQueries.member.2.MetricStat.Stat":                             "Average",
+		"MetricDataQueries.member.3.MetricStat.Stat":                             "Maximum",
+		"MetricDataQueries.member.3.MetricStat.Period":                           "60",
+		"MetricDataQueries.member.3.MetricStat.Metric.Dimensions.member.2.Name":  "ServiceName",
+		"MetricDataQueries.member.3.MetricStat.Metric.Dimensions.member.2.Value": "worker",
+	}
+	for key, value := range expected {
+		if len(form[key]) != 1 || form[key][0] != value {
+			t.Errorf("Expected %s=%s but got %v", key, value, form[key])
+		}
+	}
+}
@@ -1,19 +1,87 @@
 package scalers
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+const (
+	defaultAwsRoleSessionName = "keda"
+	// defaultAwsWebIdentityTokenFile is where the EKS pod identity webhook projects the service account token
+	defaultAwsWebIdentityTokenFile = "/var/run/secrets/eks.amazonaws.com/serviceaccount/token"
+)
+
+var awsRoleSessionNameRegex = regexp.MustCompile(`^[\w+=,.@-]{2,64}$`)
 
 type awsAuthorizationMetadata struct {
-	awsRoleArn string
+	awsRoleArn         string
+	awsRoleSessionName string
 
 	awsAccessKeyID     string
 	awsSecretAccessKey string
 
+	// web identity, the role awsWebIdentityRoleArn is assumed with the token in awsWebIdentityTokenFile,
+	// awsRoleArn is then assumed with the credentials of this role (role chaining)
+	useWebIdentity          bool
+	awsWebIdentityRoleArn   string
+	awsWebIdentityTokenFile string
+
 	podIdentityOwner bool
 }
 
-func getAwsAuthorization(authParams, metadata, resolvedEnv map[string]string) (awsAuthorizationMetadata, error) {
+// awsCredentialsCache shares the credentials of assumed roles between all AWS scalers, the cached credentials
+// are refreshed by the AWS SDK when they expire, so STS is not called on every metrics request
+var awsCredentialsCache = struct {
+	sync.Mutex
+	items map[string]*credentials.Credentials
+}{items: map[string]*credentials.Credentials{}}
+
+func getAwsAuthorization(authParams, metadata, resolvedEnv map[string]string, podIdentity kedav1alpha1.PodIdentityProvider) (awsAuthorizationMetadata, error) {
 	meta := awsAuthorizationMetadata{}
 
+	if val := authParams["awsRoleSessionName"]; val != "" {
+		meta.awsRoleSessionName = val
+	} else if val := metadata["awsRoleSessionName"]; val != "" {
+		meta.awsRoleSessionName = val
+	}
+	if meta.awsRoleSessionName != "" && !awsRoleSessionNameRegex.MatchString(meta.awsRoleSessionName) {
+		return meta, fmt.Errorf("awsRoleSessionName must consist of 2 to 64 letters, digits or any of +=,.@_- but is %s", meta.awsRoleSessionName)
+	}
+
+	if podIdentity == kedav1alpha1.PodIdentityProviderAwsWebIdentity {
+		meta.podIdentityOwner = true
+		meta.useWebIdentity = true
+
+		meta.awsWebIdentityRoleArn = authParams["awsWebIdentityRoleArn"]
+		if meta.awsWebIdentityRoleArn == "" {
+			meta.awsWebIdentityRoleArn = os.Getenv("AWS_ROLE_ARN")
+		}
+		if meta.awsWebIdentityRoleArn == "" {
+			return meta, fmt.Errorf("awsWebIdentityRoleArn not found")
+		}
+
+		// the token file is part of the operator's deployment, it is never taken from a TriggerAuthentication,
+		// which could make the operator send any of its files to STS
+		meta.awsWebIdentityTokenFile = os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+		if meta.awsWebIdentityTokenFile == "" {
+			meta.awsWebIdentityTokenFile = defaultAwsWebIdentityTokenFile
+		}
+
+		// optional role in the same or another account, assumed with the credentials of the web identity role
+		meta.awsRoleArn = authParams["awsRoleArn"]
+		return meta, nil
+	}
+
 	if metadata["identityOwner"] == "operator" {
 		meta.podIdentityOwner = false
 	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
@@ -49,3 +117,57 @@ func getAwsAuthorization(authParams, metadata, resolvedEnv map[string]string) (a
 
 	return meta, nil
 }
+
+// getAwsConfig returns the session and the client config of an AWS scaler, awsEndpoint overrides the endpoint
+// of the scaled service only, STS is always called at its regional endpoint
+func getAwsConfig(awsRegion string, awsEndpoint string, auth awsAuthorizationMetadata) (*session.Session, *aws.Config) {
+	sess := session.Must(session.NewSession(&aws.Config{
+		Region: aws.String(awsRegion),
+	}))
+
+	config := &aws.Config{
+		Region: aws.String(awsRegion),
+	}
+	if awsEndpoint != "" {
+		config.Endpoint = aws.String(awsEndpoint)
+	}
+	if auth.podIdentityOwner {
+		config.Credentials = getAwsCredentials(sess, awsRegion, auth)
+	}
+	return sess, config
+}
+
+// getAwsCredentials returns the static credentials, or the cached credentials of the assumed role
+func getAwsCredentials(sess *session.Session, awsRegion string, auth awsAuthorizationMetadata) *credentials.Credentials {
+	if !auth.useWebIdentity && auth.awsRoleArn == "" {
+		return credentials.NewStaticCredentials(auth.awsAccessKeyID, auth.awsSecretAccessKey, "")
+	}
+
+	if auth.awsRoleSessionName == "" {
+		auth.awsRoleSessionName = defaultAwsRoleSessionName
+	}
+	key := strings.Join([]string{awsRegion, auth.awsWebIdentityTokenFile, auth.awsWebIdentityRoleArn, auth.awsRoleArn, auth.awsRoleSessionName}, "|")
+
+	awsCredentialsCache.Lock()
+	defer awsCredentialsCache.Unlock()
+
+	if creds, ok := awsCredentialsCache.items[key]; ok {
+		return creds
+	}
+
+	var creds *credentials.Credentials
+	setSessionName := func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = auth.awsRoleSessionName
+	}
+	if auth.useWebIdentity {
+		creds = stscreds.NewWebIdentityCredentials(sess, auth.awsWebIdentityRoleArn, auth.awsRoleSessionName, auth.awsWebIdentityTokenFile)
+		if auth.awsRoleArn != "" {
+			creds = stscreds.NewCredentials(sess.Copy(&aws.Config{Credentials: creds}), auth.awsRoleArn, setSessionName)
+		}
+	} else {
+		creds = stscreds.NewCredentials(sess, auth.awsRoleArn, setSessionName)
+	}
+
+	awsCredentialsCache.items[key] = creds
+	return creds
+}
@@ -0,0 +1,134 @@
+package scalers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/stretchr/testify/assert"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+type parseAwsAuthorizationTestData struct {
+	authParams  map[string]string
+	metadata    map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	isError     bool
+	comment     string
+}
+
+var testAwsAuthorizationData = []parseAwsAuthorizationTestData{
+	{map[string]string{"awsWebIdentityRoleArn": "arn:aws:iam::111111111111:role/keda"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAwsWebIdentity, false, "web identity"},
+	{map[string]string{"awsWebIdentityRoleArn": "arn:aws:iam::111111111111:role/keda", "awsRoleArn": "arn:aws:iam::222222222222:role/sqs", "awsRoleSessionName": "keda-orders"}, map[string]string{}, kedav1alpha1.PodIdentityProviderAwsWebIdentity, false, "web identity with role chaining"},
+	{map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAwsWebIdentity, true, "web identity without role"},
+	{map[string]string{"awsRoleArn": "arn:aws:iam::222222222222:role/sqs"}, map[string]string{"awsRoleSessionName": "keda-orders"}, "", false, "role with session name from metadata"},
+	{map[string]string{"awsRoleArn": "arn:aws:iam::222222222222:role/sqs", "awsRoleSessionName": "keda orders"}, map[string]string{}, "", true, "invalid session name"},
+}
+
+func TestParseAwsAuthorization(t *testing.T) {
+	for _, testData := range testAwsAuthorizationData {
+		_, err := getAwsAuthorization(testData.authParams, testData.metadata, map[string]string{}, testData.podIdentity)
+		if err != nil && !testData.isError {
+			t.Errorf("%s: Expected success but got error %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: Expected error but got success", testData.comment)
+		}
+	}
+}
+
+func TestParseAwsAuthorizationWebIdentityFromEnv(t *testing.T) {
+	os.Setenv("AWS_ROLE_ARN", "arn:aws:iam::111111111111:role/keda")
+	os.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", "/var/run/secrets/token")
+	defer os.Unsetenv("AWS_ROLE_ARN")
+	defer os.Unsetenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+
+	auth, err := getAwsAuthorization(map[string]string{}, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAwsWebIdentity)
+	assert.NoError(t, err)
+	assert.True(t, auth.useWebIdentity)
+	assert.True(t, auth.podIdentityOwner)
+	assert.Equal(t, "arn:aws:iam::111111111111:role/keda", auth.awsWebIdentityRoleArn)
+	assert.Equal(t, "/var/run/secrets/token", auth.awsWebIdentityTokenFile)
+}
+
+func TestParseAwsAuthorizationIgnoresTokenFileFromAuthParams(t *testing.T) {
+	auth, err := getAwsAuthorization(map[string]string{
+		"awsWebIdentityRoleArn":   "arn:aws:iam::111111111111:role/keda",
+		"awsWebIdentityTokenFile": "/etc/shadow",
+	}, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAwsWebIdentity)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultAwsWebIdentityTokenFile, auth.awsWebIdentityTokenFile)
+}
+
+func TestAwsCredentialsCache(t *testing.T) {
+	sess := session.Must(session.NewSession(&aws.Config{Region: aws.String("eu-west-1")}))
+	auth := awsAuthorizationMetadata{podIdentityOwner: true, awsRoleArn: "arn:aws:iam::222222222222:role/cache", awsRoleSessionName: "keda"}
+
+	creds := getAwsCredentials(sess, "eu-west-1", auth)
+	assert.Same(t, creds, getAwsCredentials(sess, "eu-west-1", auth))
+
+	auth.awsRoleSessionName = "keda-other"
+	assert.NotSame(t, creds, getAwsCredentials(sess, "eu-west-1", auth))
+
+	static := awsAuthorizationMetadata{podIdentityOwner: true, awsAccessKeyID: "key", awsSecretAccessKey: "secret"}
+	assert.NotSame(t, getAwsCredentials(sess, "eu-west-1", static), getAwsCredentials(sess, "eu-west-1", static))
+}
+
+func TestAwsWebIdentityRoleChaining(t *testing.T) {
+	tokenFile, err := ioutil.TempFile("", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tokenFile.Name())
+	_, _ = tokenFile.WriteString("service-account-token")
+	tokenFile.Close()
+
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error("Could not parse request:", err)
+		}
+		action := r.Form.Get("Action")
+		actions = append(actions, action)
+
+		switch action {
+		case "AssumeRoleWithWebIdentity":
+			assert.Equal(t, "service-account-token", r.Form.Get("WebIdentityToken"))
+			assert.Equal(t, "arn:aws:iam::111111111111:role/keda", r.Form.Get("RoleArn"))
+			assert.Equal(t, "keda-orders", r.Form.Get("RoleSessionName"))
+		case "AssumeRole":
+			assert.Equal(t, "arn:aws:iam::222222222222:role/chained", r.Form.Get("RoleArn"))
+			assert.Equal(t, "keda-orders", r.Form.Get("RoleSessionName"))
+			// the chained role is assumed with the credentials of the web identity role
+			assert.Contains(t, r.Header.Get("Authorization"), "Credential=WEBIDENTITYKEY/")
+		}
+
+		fmt.Fprintf(w, `<%[1]sResponse><%[1]sResult><Credentials>
+<AccessKeyId>%[2]s</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>token</SessionToken><Expiration>2100-01-01T00:00:00Z</Expiration>
+</Credentials></%[1]sResult></%[1]sResponse>`, action, map[string]string{"AssumeRoleWithWebIdentity": "WEBIDENTITYKEY", "AssumeRole": "CHAINEDKEY"}[action])
+	}))
+	defer server.Close()
+
+	sess := session.Must(session.NewSession(&aws.Config{Region: aws.String("eu-west-1"), Endpoint: aws.String(server.URL)}))
+
+	// the token file is only taken from the environment of the operator
+	os.Setenv("AWS_WEB_IDENTITY_TOKEN_FILE", tokenFile.Name())
+	defer os.Unsetenv("AWS_WEB_IDENTITY_TOKEN_FILE")
+	auth, err := getAwsAuthorization(map[string]string{
+		"awsWebIdentityRoleArn": "arn:aws:iam::111111111111:role/keda",
+		"awsRoleArn":            "arn:aws:iam::222222222222:role/chained",
+		"awsRoleSessionName":    "keda-orders",
+	}, map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderAwsWebIdentity)
+	assert.NoError(t, err)
+
+	value, err := getAwsCredentials(sess, "eu-west-1", auth).Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "CHAINEDKEY", value.AccessKeyID)
+	assert.Equal(t, []string{"AssumeRoleWithWebIdentity", "AssumeRole"}, actions)
+}
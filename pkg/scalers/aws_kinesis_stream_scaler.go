@@ -5,11 +5,6 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/kinesis"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -30,10 +25,11 @@ type awsKinesisStreamScaler struct {
 }
 
 type awsKinesisStreamMetadata struct {
-	targetShardCount int
-	streamName       string
-	awsRegion        string
-	awsAuthorization awsAuthorizationMetadata
+	targetShardCount     int
+	activationShardCount float64
+	streamName           string
+	awsRegion            string
+	awsAuthorization     awsAuthorizationMetadata
 }
 
 var kinesisStreamLog = logf.Log.WithName("aws_kinesis_stream_scaler")
@@ -76,10 +72,16 @@ func parseAwsKinesisStreamMetadata(config *ScalerConfig) (*awsKinesisStreamMetad
 		return nil, fmt.Errorf("no awsRegion given")
 	}
 
-	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	activation, err := config.GetActivationThreshold("activationShardCount", 0)
 	if err != nil {
 		return nil, err
 	}
+	meta.activationShardCount = activation
 
 	meta.awsAuthorization = auth
 
@@ -94,7 +96,7 @@ func (s *awsKinesisStreamScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return count > 0, nil
+	return float64(count) > s.metadata.activationShardCount, nil
 }
 
 func (s *awsKinesisStreamScaler) Close() error {
@@ -140,27 +142,7 @@ func (s *awsKinesisStreamScaler) GetAwsKinesisOpenShardCount() (int64, error) {
 		StreamName: &s.metadata.streamName,
 	}
 
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(s.metadata.awsRegion),
-	}))
-
-	var kinesisClinent *kinesis.Kinesis
-	if s.metadata.awsAuthorization.podIdentityOwner {
-		creds := credentials.NewStaticCredentials(s.metadata.awsAuthorization.awsAccessKeyID, s.metadata.awsAuthorization.awsSecretAccessKey, "")
-
-		if s.metadata.awsAuthorization.awsRoleArn != "" {
-			creds = stscreds.NewCredentials(sess, s.metadata.awsAuthorization.awsRoleArn)
-		}
-
-		kinesisClinent = kinesis.New(sess, &aws.Config{
-			Region:      aws.String(s.metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		kinesisClinent = kinesis.New(sess, &aws.Config{
-			Region: aws.String(s.metadata.awsRegion),
-		})
-	}
+	kinesisClinent := kinesis.New(getAwsConfig(s.metadata.awsRegion, "", s.metadata.awsAuthorization))
 
 	output, err := kinesisClinent.DescribeStreamSummary(input)
 	if err != nil {
@@ -7,11 +7,7 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
-
 	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -24,8 +20,10 @@ import (
 )
 
 const (
-	awsSqsQueueMetricName    = "ApproximateNumberOfMessages"
-	targetQueueLengthDefault = 5
+	awsSqsQueueMetricName         = "ApproximateNumberOfMessages"
+	awsSqsQueueInFlightMetricName = "ApproximateNumberOfMessagesNotVisible"
+	awsSqsQueueDelayedMetricName  = "ApproximateNumberOfMessagesDelayed"
+	targetQueueLengthDefault      = 5
 )
 
 type awsSqsQueueScaler struct {
@@ -33,11 +31,15 @@ type awsSqsQueueScaler struct {
 }
 
 type awsSqsQueueMetadata struct {
-	targetQueueLength int
-	queueURL          string
-	queueName         string
-	awsRegion         string
-	awsAuthorization  awsAuthorizationMetadata
+	targetQueueLength     int
+	activationQueueLength float64
+	queueURL              string
+	queueName             string
+	awsRegion             string
+	awsEndpoint           string
+	awsAuthorization      awsAuthorizationMetadata
+	scaleOnInFlight       bool
+	scaleOnDelayed        bool
 }
 
 var sqsQueueLog = logf.Log.WithName("aws_sqs_queue_scaler")
@@ -93,11 +95,35 @@ func parseAwsSqsQueueMetadata(config *ScalerConfig) (*awsSqsQueueMetadata, error
 		return nil, fmt.Errorf("no awsRegion given")
 	}
 
-	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	meta.awsEndpoint = config.TriggerMetadata["awsEndpoint"]
+
+	if val, ok := config.TriggerMetadata["scaleOnInFlight"]; ok && val != "" {
+		scaleOnInFlight, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("scaleOnInFlight has invalid value: %s", err)
+		}
+		meta.scaleOnInFlight = scaleOnInFlight
+	}
+
+	if val, ok := config.TriggerMetadata["scaleOnDelayed"]; ok && val != "" {
+		scaleOnDelayed, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("scaleOnDelayed has invalid value: %s", err)
+		}
+		meta.scaleOnDelayed = scaleOnDelayed
+	}
+
+	auth, err := getAwsAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
 	if err != nil {
 		return nil, err
 	}
 
+	activation, err := config.GetActivationThreshold("activationQueueLength", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationQueueLength = activation
+
 	meta.awsAuthorization = auth
 
 	return &meta, nil
@@ -111,7 +137,7 @@ func (s *awsSqsQueueScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return length > 0, nil
+	return float64(length) > s.metadata.activationQueueLength, nil
 }
 
 func (s *awsSqsQueueScaler) Close() error {
@@ -151,43 +177,45 @@ func (s *awsSqsQueueScaler) GetMetrics(ctx context.Context, metricName string, m
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
+// getAwsSqsQueueAttributeNames returns the attributes summed up to the queue length,
+// messages in flight and delayed messages are counted only if configured
+func (s *awsSqsQueueScaler) getAwsSqsQueueAttributeNames() []string {
+	attributeNames := []string{awsSqsQueueMetricName}
+	if s.metadata.scaleOnInFlight {
+		attributeNames = append(attributeNames, awsSqsQueueInFlightMetricName)
+	}
+	if s.metadata.scaleOnDelayed {
+		attributeNames = append(attributeNames, awsSqsQueueDelayedMetricName)
+	}
+	return attributeNames
+}
+
 // Get SQS Queue Length
 func (s *awsSqsQueueScaler) GetAwsSqsQueueLength() (int32, error) {
+	attributeNames := s.getAwsSqsQueueAttributeNames()
 	input := &sqs.GetQueueAttributesInput{
-		AttributeNames: aws.StringSlice([]string{awsSqsQueueMetricName}),
+		AttributeNames: aws.StringSlice(attributeNames),
 		QueueUrl:       aws.String(s.metadata.queueURL),
 	}
 
-	sess := session.Must(session.NewSession(&aws.Config{
-		Region: aws.String(s.metadata.awsRegion),
-	}))
-
-	var sqsClient *sqs.SQS
-	if s.metadata.awsAuthorization.podIdentityOwner {
-		creds := credentials.NewStaticCredentials(s.metadata.awsAuthorization.awsAccessKeyID, s.metadata.awsAuthorization.awsSecretAccessKey, "")
-
-		if s.metadata.awsAuthorization.awsRoleArn != "" {
-			creds = stscreds.NewCredentials(sess, s.metadata.awsAuthorization.awsRoleArn)
-		}
-
-		sqsClient = sqs.New(sess, &aws.Config{
-			Region:      aws.String(s.metadata.awsRegion),
-			Credentials: creds,
-		})
-	} else {
-		sqsClient = sqs.New(sess, &aws.Config{
-			Region: aws.String(s.metadata.awsRegion),
-		})
-	}
+	sqsClient := sqs.New(getAwsConfig(s.metadata.awsRegion, s.metadata.awsEndpoint, s.metadata.awsAuthorization))
 
 	output, err := sqsClient.GetQueueAttributes(input)
 	if err != nil {
 		return -1, err
 	}
 
-	approximateNumberOfMessages, err := strconv.Atoi(*output.Attributes[awsSqsQueueMetricName])
-	if err != nil {
-		return -1, err
+	approximateNumberOfMessages := 0
+	for _, attributeName := range attributeNames {
+		value, ok := output.Attributes[attributeName]
+		if !ok || value == nil {
+			return -1, fmt.Errorf("attribute %s not returned for queue %s", attributeName, s.metadata.queueName)
+		}
+		count, err := strconv.Atoi(*value)
+		if err != nil {
+			return -1, err
+		}
+		approximateNumberOfMessages += count
 	}
 
 	return int32(approximateNumberOfMessages), nil
@@ -1,7 +1,12 @@
 package scalers
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sqs"
 )
 
 const (
@@ -128,6 +133,29 @@ var testAWSSQSMetadata = []parseAWSSQSMetadataTestData{
 		},
 		false,
 		"with AWS Role assigned on KEDA operator itself"},
+	{map[string]string{
+		"queueURL":        testAWSSQSProperQueueURL,
+		"awsRegion":       "eu-west-1",
+		"awsEndpoint":     "http://localhost:4566",
+		"scaleOnInFlight": "true",
+		"scaleOnDelayed":  "false"},
+		testAWSSQSAuthentication,
+		false,
+		"with custom endpoint, in flight and delayed messages"},
+	{map[string]string{
+		"queueURL":        testAWSSQSProperQueueURL,
+		"awsRegion":       "eu-west-1",
+		"scaleOnInFlight": "yes please"},
+		testAWSSQSAuthentication,
+		true,
+		"invalid scaleOnInFlight"},
+	{map[string]string{
+		"queueURL":       testAWSSQSProperQueueURL,
+		"awsRegion":      "eu-west-1",
+		"scaleOnDelayed": "1.5"},
+		testAWSSQSAuthentication,
+		true,
+		"invalid scaleOnDelayed"},
 }
 
 var awsSQSMetricIdentifiers = []awsSQSMetricIdentifier{
@@ -152,7 +180,7 @@ func TestAWSSQSGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockAWSSQSScaler := awsSqsQueueScaler{meta}
+		mockAWSSQSScaler := awsSqsQueueScaler{metadata: meta}
 
 		metricSpec := mockAWSSQSScaler.GetMetricSpecForScaling()
 		metricName := metricSpec[0].External.Metric.Name
@@ -161,3 +189,85 @@ func TestAWSSQSGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestAWSSQSGetQueueLengthFromEndpoint(t *testing.T) {
+	var requestedAttributes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Error("Could not parse request:", err)
+		}
+		requestedAttributes = nil
+		for i := 1; r.Form.Get(fmt.Sprintf("AttributeName.%d", i)) != ""; i++ {
+			requestedAttributes = append(requestedAttributes, r.Form.Get(fmt.Sprintf("AttributeName.%d", i)))
+		}
+		fmt.Fprint(w, `<GetQueueAttributesResponse><GetQueueAttributesResult>
+<Attribute><Name>ApproximateNumberOfMessages</Name><Value>3</Value></Attribute>
+<Attribute><Name>ApproximateNumberOfMessagesNotVisible</Name><Value>4</Value></Attribute>
+<Attribute><Name>ApproximateNumberOfMessagesDelayed</Name><Value>5</Value></Attribute>
+</GetQueueAttributesResult><ResponseMetadata><RequestId>test</RequestId></ResponseMetadata></GetQueueAttributesResponse>`)
+	}))
+	defer server.Close()
+
+	testData := []struct {
+		scaleOnInFlight string
+		scaleOnDelayed  string
+		length          int32
+		attributes      int
+	}{
+		{"false", "false", 3, 1},
+		{"true", "false", 7, 2},
+		{"true", "true", 12, 3},
+	}
+
+	for _, d := range testData {
+		metadata := map[string]string{
+			"queueURL":        server.URL + "/000000000000/orders",
+			"awsRegion":       "eu-west-1",
+			"awsEndpoint":     server.URL,
+			"scaleOnInFlight": d.scaleOnInFlight,
+			"scaleOnDelayed":  d.scaleOnDelayed,
+		}
+		meta, err := parseAwsSqsQueueMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: testAWSSQSAuthentication})
+		if err != nil {
+			t.Fatal("Could not parse metadata:", err)
+		}
+		scaler := awsSqsQueueScaler{metadata: meta}
+
+		length, err := scaler.GetAwsSqsQueueLength()
+		if err != nil {
+			t.Fatal("Expected success but got error:", err)
+		}
+		if length != d.length {
+			t.Errorf("Expected queue length %d but got %d", d.length, length)
+		}
+		if len(requestedAttributes) != d.attributes {
+			t.Errorf("Expected %d requested attributes but got %v", d.attributes, requestedAttributes)
+		}
+	}
+}
+
+func TestAWSSQSEndpointIsNotUsedForSTS(t *testing.T) {
+	meta, err := parseAwsSqsQueueMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{
+			"queueURL":    "http://localhost:4566/000000000000/orders",
+			"awsRegion":   "eu-west-1",
+			"awsEndpoint": "http://localhost:4566",
+		},
+		AuthParams: map[string]string{"awsRoleArn": "arn:aws:iam::000000000000:role/keda"},
+	})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	// the assumed role credentials are built on the session, the endpoint must only be set on the SQS client
+	sess, config := getAwsConfig(meta.awsRegion, meta.awsEndpoint, meta.awsAuthorization)
+	if sess.Config.Endpoint != nil {
+		t.Errorf("Expected no endpoint on the session but got %s", *sess.Config.Endpoint)
+	}
+	if config.Endpoint == nil || *config.Endpoint != "http://localhost:4566" {
+		t.Errorf("Expected the endpoint on the SQS client config but got %v", config.Endpoint)
+	}
+	if client := sqs.New(sess, config); client.Endpoint != "http://localhost:4566" {
+		t.Errorf("Expected the SQS client to use the endpoint but got %s", client.Endpoint)
+	}
+}
@@ -31,12 +31,13 @@ type azureBlobScaler struct {
 }
 
 type azureBlobMetadata struct {
-	targetBlobCount   int
-	blobContainerName string
-	blobDelimiter     string
-	blobPrefix        string
-	connection        string
-	accountName       string
+	targetBlobCount     int
+	activationBlobCount float64
+	blobContainerName   string
+	blobDelimiter       string
+	blobPrefix          string
+	connection          string
+	accountName         string
 }
 
 var azureBlobLog = logf.Log.WithName("azure_blob_scaler")
@@ -114,6 +115,12 @@ func parseAzureBlobMetadata(config *ScalerConfig) (*azureBlobMetadata, kedav1alp
 		return nil, "", fmt.Errorf("pod identity %s not supported for azure storage blobs", config.PodIdentity)
 	}
 
+	activation, err := config.GetActivationThreshold("activationBlobCount", 0)
+	if err != nil {
+		return nil, "", err
+	}
+	meta.activationBlobCount = activation
+
 	return &meta, config.PodIdentity, nil
 }
 
@@ -134,7 +141,7 @@ func (s *azureBlobScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return length > 0, nil
+	return float64(length) > s.metadata.activationBlobCount, nil
 }
 
 func (s *azureBlobScaler) Close() error {
@@ -37,8 +37,9 @@ type azureEventHubScaler struct {
 }
 
 type eventHubMetadata struct {
-	eventHubInfo azure.EventHubInfo
-	threshold    int64
+	eventHubInfo        azure.EventHubInfo
+	threshold           int64
+	activationThreshold float64
 }
 
 // NewAzureEventHubScaler creates a new scaler for eventHub
@@ -75,6 +76,12 @@ func parseAzureEventHubMetadata(config *ScalerConfig) (*eventHubMetadata, error)
 		meta.threshold = threshold
 	}
 
+	activation, err := config.GetActivationThreshold("activationUnprocessedEventThreshold", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationThreshold = activation
+
 	if config.AuthParams["storageConnection"] != "" {
 		meta.eventHubInfo.StorageConnection = config.AuthParams["storageConnection"]
 	} else if config.TriggerMetadata["storageConnectionFromEnv"] != "" {
@@ -178,6 +185,7 @@ func (scaler *azureEventHubScaler) IsActive(ctx context.Context) (bool, error) {
 
 	partitionIDs := runtimeInfo.PartitionIDs
 
+	totalUnprocessedEventCount := int64(0)
 	for i := 0; i < len(partitionIDs); i++ {
 		partitionID := partitionIDs[i]
 
@@ -192,7 +200,9 @@ func (scaler *azureEventHubScaler) IsActive(ctx context.Context) (bool, error) {
 			return false, fmt.Errorf("unable to get unprocessedEventCount for isActive: %s", err)
 		}
 
-		if unprocessedEventCount > 0 {
+		// Return as soon as the unprocessed events exceed the activation threshold
+		totalUnprocessedEventCount += unprocessedEventCount
+		if float64(totalUnprocessedEventCount) > scaler.metadata.activationThreshold {
 			return true, nil
 		}
 	}
@@ -40,13 +40,14 @@ type azureLogAnalyticsScaler struct {
 }
 
 type azureLogAnalyticsMetadata struct {
-	tenantID     string
-	clientID     string
-	clientSecret string
-	workspaceID  string
-	podIdentity  string
-	query        string
-	threshold    int64
+	tenantID            string
+	clientID            string
+	clientSecret        string
+	workspaceID         string
+	podIdentity         string
+	query               string
+	threshold           int64
+	activationThreshold float64
 }
 
 type sessionCache struct {
@@ -183,6 +184,12 @@ func parseAzureLogAnalyticsMetadata(config *ScalerConfig) (*azureLogAnalyticsMet
 		return nil, fmt.Errorf("error parsing metadata. Details: threshold was not found in metadata. Check your ScaledObject configuration")
 	}
 
+	activation, err := config.GetActivationThreshold("activationThreshold", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationThreshold = activation
+
 	return &meta, nil
 }
 
@@ -194,7 +201,7 @@ func (s *azureLogAnalyticsScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, fmt.Errorf("failed to execute IsActive function. Scaled object: %s. Namespace: %s. Inner Error: %v", s.name, s.namespace, err)
 	}
 
-	return s.cache.metricValue > 0, nil
+	return float64(s.cache.metricValue) > s.metadata.activationThreshold, nil
 }
 
 func (s *azureLogAnalyticsScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
@@ -30,8 +30,9 @@ type azureMonitorScaler struct {
 }
 
 type azureMonitorMetadata struct {
-	azureMonitorInfo azure.MonitorInfo
-	targetValue      int
+	azureMonitorInfo      azure.MonitorInfo
+	targetValue           int
+	activationTargetValue float64
 }
 
 var azureMonitorLog = logf.Log.WithName("azure_monitor_scaler")
@@ -145,6 +146,12 @@ func parseAzureMonitorMetadata(config *ScalerConfig) (*azureMonitorMetadata, err
 		return nil, fmt.Errorf("azure Monitor doesn't support pod identity %s", config.PodIdentity)
 	}
 
+	activation, err := config.GetActivationThreshold("activationTargetValue", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationTargetValue = activation
+
 	return &meta, nil
 }
 
@@ -156,7 +163,7 @@ func (s *azureMonitorScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return val > 0, nil
+	return float64(val) > s.metadata.activationTargetValue, nil
 }
 
 func (s *azureMonitorScaler) Close() error {
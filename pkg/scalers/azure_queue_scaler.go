@@ -30,10 +30,11 @@ type azureQueueScaler struct {
 }
 
 type azureQueueMetadata struct {
-	targetQueueLength int
-	queueName         string
-	connection        string
-	accountName       string
+	targetQueueLength     int
+	activationQueueLength float64
+	queueName             string
+	connection            string
+	accountName           string
 }
 
 var azureQueueLog = logf.Log.WithName("azure_queue_scaler")
@@ -104,6 +105,12 @@ func parseAzureQueueMetadata(config *ScalerConfig) (*azureQueueMetadata, kedav1a
 		return nil, "", fmt.Errorf("pod identity %s not supported for azure storage queues", config.PodIdentity)
 	}
 
+	activation, err := config.GetActivationThreshold("activationQueueLength", 0)
+	if err != nil {
+		return nil, "", err
+	}
+	meta.activationQueueLength = activation
+
 	return &meta, config.PodIdentity, nil
 }
 
@@ -122,7 +129,7 @@ func (s *azureQueueScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return length > 0, nil
+	return float64(length) > s.metadata.activationQueueLength, nil
 }
 
 func (s *azureQueueScaler) Close() error {
@@ -37,13 +37,14 @@ type azureServiceBusScaler struct {
 }
 
 type azureServiceBusMetadata struct {
-	targetLength     int
-	queueName        string
-	topicName        string
-	subscriptionName string
-	connection       string
-	entityType       entityType
-	namespace        string
+	targetLength           int
+	activationMessageCount float64
+	queueName              string
+	topicName              string
+	subscriptionName       string
+	connection             string
+	entityType             entityType
+	namespace              string
 }
 
 // NewAzureServiceBusScaler creates a new AzureServiceBusScaler
@@ -124,6 +125,12 @@ func parseAzureServiceBusMetadata(config *ScalerConfig) (*azureServiceBusMetadat
 		return nil, fmt.Errorf("azure service bus doesn't support pod identity %s", config.PodIdentity)
 	}
 
+	activation, err := config.GetActivationThreshold("activationMessageCount", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationMessageCount = activation
+
 	return &meta, nil
 }
 
@@ -135,7 +142,7 @@ func (s *azureServiceBusScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return length > 0, nil
+	return float64(length) > s.metadata.activationMessageCount, nil
 }
 
 // Close - nothing to close for SB
@@ -52,10 +52,16 @@ func parseResourceMetadata(config *ScalerConfig) (*cpuMemoryMetadata, error) {
 	}
 	switch meta.Type {
 	case v2beta2.ValueMetricType:
-		valueQuantity := resource.MustParse(value)
+		valueQuantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value: %s", err)
+		}
 		meta.Value = &valueQuantity
 	case v2beta2.AverageValueMetricType:
-		averageValueQuantity := resource.MustParse(value)
+		averageValueQuantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing value: %s", err)
+		}
 		meta.AverageValue = &averageValueQuantity
 	case v2beta2.UtilizationMetricType:
 		valueNum, err := strconv.Atoi(value)
@@ -3,17 +3,23 @@ package scalers
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
 	"k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
 
 	kedautil "github.com/kedacore/keda/pkg/util"
 )
@@ -21,17 +27,47 @@ import (
 const (
 	defaultDesiredReplicas = 1
 	cronMetricType         = "External"
+	cronDateFormat         = "2006-01-02"
+	// cronMaxLookback limits the search for the previous occurrence of a schedule,
+	// a schedule without occurrence in this period is considered to have never occurred
+	cronMaxLookback = 2 * 366 * 24 * time.Hour
+	// defaultCronCalendarRefreshInterval is used if the scaler doesn't know its polling interval
+	defaultCronCalendarRefreshInterval = 30 * time.Second
 )
 
 type cronScaler struct {
-	metadata *cronMetadata
+	metadata   *cronMetadata
+	kubeClient client.Reader
+	namespace  string
+
+	// the parsed holiday calendar is reused until refreshInterval passes
+	calendarLock     sync.Mutex
+	calendarDates    map[string]bool
+	calendarReadTime time.Time
+	refreshInterval  time.Duration
 }
 
 type cronMetadata struct {
-	start           string
-	end             string
-	timezone        string
-	desiredReplicas int64
+	timezone string
+	location *time.Location
+	windows  []cronWindow
+	// windowsSpec is the raw windows metadata, empty if the window is given by start, end and desiredReplicas
+	windowsSpec string
+
+	excludeDates           map[string]bool
+	holidayCalendarName    string
+	holidayCalendarKey     string
+	holidayCalendarDefined bool
+}
+
+// cronWindow is a single scaling window, the scale target is scaled to desiredReplicas between start and end
+type cronWindow struct {
+	Start           string `json:"start"`
+	End             string `json:"end"`
+	DesiredReplicas *int64 `json:"desiredReplicas"`
+
+	startSchedule cron.Schedule
+	endSchedule   cron.Schedule
 }
 
 var cronLog = logf.Log.WithName("cron_scaler")
@@ -43,23 +79,21 @@ func NewCronScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing cron metadata: %s", parseErr)
 	}
 
-	return &cronScaler{
-		metadata: meta,
-	}, nil
-}
-
-func getCronTime(location *time.Location, spec string) (int64, error) {
-	c := cron.New(cron.WithLocation(location))
-	_, err := c.AddFunc(spec, func() { _ = fmt.Sprintf("Cron initialized for location %s", location.String()) })
-	if err != nil {
-		return 0, err
+	if meta.holidayCalendarDefined && config.KubeClient == nil {
+		return nil, fmt.Errorf("holidayCalendarConfigMap requires a kubernetes client")
 	}
 
-	c.Start()
-	cronTime := c.Entries()[0].Next.Unix()
-	c.Stop()
+	refreshInterval := config.PollingInterval
+	if refreshInterval <= 0 {
+		refreshInterval = defaultCronCalendarRefreshInterval
+	}
 
-	return cronTime, nil
+	return &cronScaler{
+		metadata:        meta,
+		kubeClient:      config.KubeClient,
+		namespace:       config.Namespace,
+		refreshInterval: refreshInterval,
+	}, nil
 }
 
 func parseCronMetadata(config *ScalerConfig) (*cronMetadata, error) {
@@ -73,56 +107,233 @@ func parseCronMetadata(config *ScalerConfig) (*cronMetadata, error) {
 	} else {
 		return nil, fmt.Errorf("no timezone specified. %s", config.TriggerMetadata)
 	}
-	if val, ok := config.TriggerMetadata["start"]; ok && val != "" {
-		meta.start = val
+	location, err := time.LoadLocation(meta.timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load timezone. Error: %s", err)
+	}
+	meta.location = location
+
+	if val, ok := config.TriggerMetadata["windows"]; ok && val != "" {
+		for _, key := range []string{"start", "end", "desiredReplicas"} {
+			if config.TriggerMetadata[key] != "" {
+				return nil, fmt.Errorf("%s must not be used together with windows", key)
+			}
+		}
+		if err := yaml.UnmarshalStrict([]byte(val), &meta.windows); err != nil {
+			return nil, fmt.Errorf("error parsing windows: %s", err)
+		}
+		if len(meta.windows) == 0 {
+			return nil, fmt.Errorf("no windows specified. %s", config.TriggerMetadata)
+		}
+		meta.windowsSpec = val
+	} else {
+		window, err := parseCronSingleWindow(config.TriggerMetadata)
+		if err != nil {
+			return nil, err
+		}
+		meta.windows = []cronWindow{window}
+	}
+
+	for i := range meta.windows {
+		if err := parseCronWindowSchedules(&meta.windows[i]); err != nil {
+			return nil, fmt.Errorf("error parsing window #%d: %s", i, err)
+		}
+	}
+
+	meta.excludeDates = map[string]bool{}
+	if val, ok := config.TriggerMetadata["excludeDates"]; ok && val != "" {
+		if err := parseCronDates(val, meta.excludeDates); err != nil {
+			return nil, fmt.Errorf("error parsing excludeDates: %s", err)
+		}
+	}
+
+	if val, ok := config.TriggerMetadata["holidayCalendarConfigMap"]; ok && val != "" {
+		meta.holidayCalendarName = val
+		meta.holidayCalendarKey = config.TriggerMetadata["holidayCalendarKey"]
+		meta.holidayCalendarDefined = true
+	} else if config.TriggerMetadata["holidayCalendarKey"] != "" {
+		return nil, fmt.Errorf("holidayCalendarKey must be used together with holidayCalendarConfigMap")
+	}
+
+	return &meta, nil
+}
+
+// parseCronSingleWindow parses the window given by start, end and desiredReplicas
+func parseCronSingleWindow(metadata map[string]string) (cronWindow, error) {
+	window := cronWindow{}
+	if val, ok := metadata["start"]; ok && val != "" {
+		window.Start = val
 	} else {
-		return nil, fmt.Errorf("no start schedule specified. %s", config.TriggerMetadata)
+		return window, fmt.Errorf("no start schedule specified. %s", metadata)
 	}
-	if val, ok := config.TriggerMetadata["end"]; ok && val != "" {
-		meta.end = val
+	if val, ok := metadata["end"]; ok && val != "" {
+		window.End = val
 	} else {
-		return nil, fmt.Errorf("no end schedule specified. %s", config.TriggerMetadata)
+		return window, fmt.Errorf("no end schedule specified. %s", metadata)
 	}
-	if val, ok := config.TriggerMetadata["desiredReplicas"]; ok && val != "" {
+	if val, ok := metadata["desiredReplicas"]; ok && val != "" {
 		metadataDesiredReplicas, err := strconv.Atoi(val)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing desiredReplicas metadata. %s", config.TriggerMetadata)
+			return window, fmt.Errorf("error parsing desiredReplicas metadata. %s", metadata)
 		}
 
-		meta.desiredReplicas = int64(metadataDesiredReplicas)
+		desiredReplicas := int64(metadataDesiredReplicas)
+		window.DesiredReplicas = &desiredReplicas
 	} else {
-		return nil, fmt.Errorf("no DesiredReplicas specified. %s", config.TriggerMetadata)
+		return window, fmt.Errorf("no DesiredReplicas specified. %s", metadata)
 	}
+	return window, nil
+}
 
-	return &meta, nil
+func parseCronWindowSchedules(window *cronWindow) error {
+	if window.Start == "" {
+		return fmt.Errorf("no start schedule specified")
+	}
+	if window.End == "" {
+		return fmt.Errorf("no end schedule specified")
+	}
+	if window.DesiredReplicas == nil {
+		return fmt.Errorf("no desiredReplicas specified")
+	}
+
+	var err error
+	if window.startSchedule, err = cron.ParseStandard(window.Start); err != nil {
+		return fmt.Errorf("error parsing start schedule: %s", err)
+	}
+	if window.endSchedule, err = cron.ParseStandard(window.End); err != nil {
+		return fmt.Errorf("error parsing end schedule: %s", err)
+	}
+	return nil
 }
 
-// IsActive checks if the startTime or endTime has reached
-func (s *cronScaler) IsActive(ctx context.Context) (bool, error) {
-	location, err := time.LoadLocation(s.metadata.timezone)
+// parseCronDates adds the dates (YYYY-MM-DD) separated by commas or whitespace to dates,
+// everything following a # up to the end of the line is a comment
+func parseCronDates(value string, dates map[string]bool) error {
+	for _, line := range strings.Split(value, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		for _, date := range strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\r' }) {
+			if _, err := time.Parse(cronDateFormat, date); err != nil {
+				return fmt.Errorf("invalid date %s, expected format YYYY-MM-DD", date)
+			}
+			dates[date] = true
+		}
+	}
+	return nil
+}
+
+// getPreviousCronTime returns the latest occurrence of the schedule not after now,
+// the period searched is doubled until an occurrence is found or cronMaxLookback is reached
+func getPreviousCronTime(schedule cron.Schedule, now time.Time) (time.Time, bool) {
+	for lookback := time.Minute; lookback <= cronMaxLookback; lookback *= 2 {
+		var previous time.Time
+		for t := schedule.Next(now.Add(-lookback)); !t.IsZero() && !t.After(now); t = schedule.Next(t) {
+			previous = t
+		}
+		if !previous.IsZero() {
+			return previous, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// isActiveAt returns true if the previous occurrence of start is later than the previous occurrence of end,
+// the window is then open until the next occurrence of end. The result depends only on now, so it is the same
+// regardless of when the scale loop was started.
+func (w *cronWindow) isActiveAt(now time.Time) bool {
+	previousStart, started := getPreviousCronTime(w.startSchedule, now)
+	if !started {
+		return false
+	}
+	previousEnd, ended := getPreviousCronTime(w.endSchedule, now)
+	return !ended || previousStart.After(previousEnd)
+}
+
+// getExcludedDates returns the dates of excludeDates and of the holiday calendar ConfigMap, the ConfigMap is read
+// again once per polling interval, so changes of the calendar apply without updating the ScaledObject
+func (s *cronScaler) getExcludedDates(ctx context.Context) (map[string]bool, error) {
+	if !s.metadata.holidayCalendarDefined {
+		return s.metadata.excludeDates, nil
+	}
+
+	s.calendarLock.Lock()
+	defer s.calendarLock.Unlock()
+
+	if s.calendarDates != nil && time.Since(s.calendarReadTime) < s.refreshInterval {
+		return s.calendarDates, nil
+	}
+
+	dates, err := s.readHolidayCalendar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.calendarDates = dates
+	s.calendarReadTime = time.Now()
+	return dates, nil
+}
+
+// readHolidayCalendar reads the holiday calendar ConfigMap and returns its dates together with excludeDates
+func (s *cronScaler) readHolidayCalendar(ctx context.Context) (map[string]bool, error) {
+	configMap := &corev1.ConfigMap{}
+	err := s.kubeClient.Get(ctx, types.NamespacedName{Name: s.metadata.holidayCalendarName, Namespace: s.namespace}, configMap)
 	if err != nil {
-		return false, fmt.Errorf("unable to load timezone. Error: %s", err)
+		return nil, fmt.Errorf("error reading holiday calendar ConfigMap %s: %s", s.metadata.holidayCalendarName, err)
 	}
 
-	nextStartTime, startTimecronErr := getCronTime(location, s.metadata.start)
-	if startTimecronErr != nil {
-		return false, fmt.Errorf("error initializing start cron: %s", startTimecronErr)
+	dates := map[string]bool{}
+	for date := range s.metadata.excludeDates {
+		dates[date] = true
 	}
 
-	nextEndTime, endTimecronErr := getCronTime(location, s.metadata.end)
-	if endTimecronErr != nil {
-		return false, fmt.Errorf("error intializing end cron: %s", endTimecronErr)
+	if s.metadata.holidayCalendarKey != "" {
+		value, ok := configMap.Data[s.metadata.holidayCalendarKey]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in holiday calendar ConfigMap %s", s.metadata.holidayCalendarKey, s.metadata.holidayCalendarName)
+		}
+		if err := parseCronDates(value, dates); err != nil {
+			return nil, fmt.Errorf("error parsing holiday calendar %s/%s: %s", s.metadata.holidayCalendarName, s.metadata.holidayCalendarKey, err)
+		}
+		return dates, nil
 	}
 
-	// Since we are considering the timestamp here and not the exact time, timezone does matter.
-	currentTime := time.Now().Unix()
-	if nextStartTime < nextEndTime && currentTime < nextStartTime {
-		return false, nil
-	} else if currentTime <= nextEndTime {
-		return true, nil
-	} else {
-		return false, nil
+	for key, value := range configMap.Data {
+		if err := parseCronDates(value, dates); err != nil {
+			return nil, fmt.Errorf("error parsing holiday calendar %s/%s: %s", s.metadata.holidayCalendarName, key, err)
+		}
+	}
+	return dates, nil
+}
+
+// getDesiredReplicas returns the highest desiredReplicas of the windows active at now,
+// false is returned if no window is active or now is an excluded date
+func (s *cronScaler) getDesiredReplicas(ctx context.Context, now time.Time) (int64, bool, error) {
+	excludedDates, err := s.getExcludedDates(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Since we are considering the date here and not the timestamp, timezone does matter.
+	now = now.In(s.metadata.location)
+	if excludedDates[now.Format(cronDateFormat)] {
+		return 0, false, nil
+	}
+
+	var desiredReplicas int64
+	active := false
+	for _, window := range s.metadata.windows {
+		if window.isActiveAt(now) && (!active || *window.DesiredReplicas > desiredReplicas) {
+			desiredReplicas = *window.DesiredReplicas
+			active = true
+		}
 	}
+	return desiredReplicas, active, nil
+}
+
+// IsActive checks if any of the windows is open
+func (s *cronScaler) IsActive(ctx context.Context) (bool, error) {
+	_, active, err := s.getDesiredReplicas(ctx, time.Now())
+	return active, err
 }
 
 func (s *cronScaler) Close() error {
@@ -137,13 +348,26 @@ func parseCronTimeFormat(s string) string {
 	return s
 }
 
+// getMetricName returns a name made from the schedules for a single window,
+// and from a hash of all windows otherwise
+func (s *cronScaler) getMetricName() string {
+	if s.metadata.windowsSpec == "" {
+		window := s.metadata.windows[0]
+		return kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s-%s", "cron", s.metadata.timezone, parseCronTimeFormat(window.Start), parseCronTimeFormat(window.End)))
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(s.metadata.windowsSpec))
+	return kedautil.NormalizeString(fmt.Sprintf("%s-%s-windows-%x", "cron", s.metadata.timezone, hash.Sum32()))
+}
+
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *cronScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
 	specReplicas := 1
 	targetMetricValue := resource.NewQuantity(int64(specReplicas), resource.DecimalSI)
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s-%s", "cron", s.metadata.timezone, parseCronTimeFormat(s.metadata.start), parseCronTimeFormat(s.metadata.end))),
+			Name: s.getMetricName(),
 		},
 		Target: v2beta2.MetricTarget{
 			Type:         v2beta2.AverageValueMetricType,
@@ -157,13 +381,13 @@ func (s *cronScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
 // GetMetrics finds the current value of the metric
 func (s *cronScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	var currentReplicas = int64(defaultDesiredReplicas)
-	isActive, err := s.IsActive(ctx)
+	desiredReplicas, isActive, err := s.getDesiredReplicas(ctx, time.Now())
 	if err != nil {
 		cronLog.Error(err, "error")
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 	if isActive {
-		currentReplicas = s.metadata.desiredReplicas
+		currentReplicas = desiredReplicas
 	}
 
 	/*******************************************************************************/
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 type parseCronMetadataTestData struct {
@@ -31,10 +34,43 @@ var testCronMetadata = []parseCronMetadataTestData{
 	{validCronMetadata, false},
 	{map[string]string{"timezone": "Asia/Kolkata", "start": "30 * * * *", "end": "45 * * * *"}, true},
 	{map[string]string{"start": "30 * * * *", "end": "45 * * * *", "desiredReplicas": "10"}, true},
+	// windows
+	{map[string]string{"timezone": "Etc/UTC", "windows": testCronWindows}, false},
+	// windows together with start
+	{map[string]string{"timezone": "Etc/UTC", "windows": testCronWindows, "start": "0 8 * * *"}, true},
+	// empty windows list
+	{map[string]string{"timezone": "Etc/UTC", "windows": "[]"}, true},
+	// window without desiredReplicas
+	{map[string]string{"timezone": "Etc/UTC", "windows": `[{"start": "0 8 * * *", "end": "0 18 * * *"}]`}, true},
+	// window with unknown field
+	{map[string]string{"timezone": "Etc/UTC", "windows": `[{"start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": 2, "replicas": 3}]`}, true},
+	// invalid schedule
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * *", "end": "0 18 * * *", "desiredReplicas": "2"}, true},
+	// invalid timezone
+	{map[string]string{"timezone": "Mars/Olympus", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "2"}, true},
+	// excludeDates
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "2", "excludeDates": "2020-12-24, 2020-12-25"}, false},
+	// invalid excludeDates
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "2", "excludeDates": "24.12.2020"}, true},
+	// holidayCalendarKey without holidayCalendarConfigMap
+	{map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "2", "holidayCalendarKey": "dates"}, true},
 }
 
+const testCronWindows = `
+- start: "0 8 * * 1-5"
+  end: "0 18 * * 1-5"
+  desiredReplicas: 5
+- start: "0 12 * * 1-5"
+  end: "0 14 * * 1-5"
+  desiredReplicas: 10
+- start: "0 22 * * *"
+  end: "0 6 * * *"
+  desiredReplicas: 2
+`
+
 var cronMetricIdentifiers = []cronMetricIdentifier{
 	{&testCronMetadata[1], "cron-Etc-UTC-00xxThu-5923xxThu"},
+	{&testCronMetadata[4], "cron-Etc-UTC-windows-d82d3fcf"},
 }
 
 var tz, _ = time.LoadLocation(validCronMetadata["timezone"])
@@ -79,7 +115,7 @@ func TestCronGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockCronScaler := cronScaler{meta}
+		mockCronScaler := cronScaler{metadata: meta}
 
 		metricSpec := mockCronScaler.GetMetricSpecForScaling()
 		metricName := metricSpec[0].External.Metric.Name
@@ -88,3 +124,139 @@ func TestCronGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestCronWindowsDesiredReplicas(t *testing.T) {
+	meta, err := parseCronMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"timezone": "Europe/Berlin", "windows": testCronWindows, "excludeDates": "2020-12-24"}})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := cronScaler{metadata: meta}
+
+	testData := []struct {
+		now             string
+		desiredReplicas int64
+		active          bool
+	}{
+		// Monday before the first window
+		{"2020-12-07T07:59:00+01:00", 0, false},
+		// start of the first window
+		{"2020-12-07T08:00:00+01:00", 5, true},
+		// overlapping windows, the highest wins
+		{"2020-12-07T13:00:00+01:00", 10, true},
+		// end of the first window
+		{"2020-12-07T18:00:00+01:00", 0, false},
+		// window spanning midnight, opened the day before
+		{"2020-12-08T03:00:00+01:00", 2, true},
+		// the same time in UTC gives the same result
+		{"2020-12-08T02:00:00Z", 2, true},
+		// Saturday, only the nightly window
+		{"2020-12-12T13:00:00+01:00", 0, false},
+		{"2020-12-12T23:00:00+01:00", 2, true},
+		// excluded date
+		{"2020-12-24T13:00:00+01:00", 0, false},
+	}
+
+	for _, d := range testData {
+		now, _ := time.Parse(time.RFC3339, d.now)
+		desiredReplicas, active, err := scaler.getDesiredReplicas(context.TODO(), now)
+		assert.NoError(t, err)
+		assert.Equal(t, d.active, active, d.now)
+		assert.Equal(t, d.desiredReplicas, desiredReplicas, d.now)
+	}
+}
+
+func TestGetPreviousCronTime(t *testing.T) {
+	meta, err := parseCronMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"timezone": "Etc/UTC", "start": "0 0 29 2 *", "end": "0 0 1 3 *", "desiredReplicas": "2"}})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	now := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	previous, found := getPreviousCronTime(meta.windows[0].startSchedule, now)
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), previous)
+
+	previous, found = getPreviousCronTime(meta.windows[0].endSchedule, now)
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), previous)
+
+	previous, found = getPreviousCronTime(meta.windows[0].endSchedule, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC))
+	assert.True(t, found)
+	assert.Equal(t, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), previous)
+}
+
+func TestCronHolidayCalendar(t *testing.T) {
+	calendar := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "holidays", Namespace: "test"},
+		Data: map[string]string{
+			"de": "# Germany\n2020-12-25\n2020-12-26\n",
+			"fr": "2020-07-14",
+		},
+	}
+	kubeClient := fake.NewFakeClient(calendar)
+	metadata := map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "2", "holidayCalendarConfigMap": "holidays"}
+
+	testData := []struct {
+		key    string
+		now    time.Time
+		active bool
+	}{
+		{"", time.Date(2020, 12, 25, 12, 0, 0, 0, time.UTC), false},
+		{"", time.Date(2020, 7, 14, 12, 0, 0, 0, time.UTC), false},
+		{"", time.Date(2020, 7, 15, 12, 0, 0, 0, time.UTC), true},
+		{"de", time.Date(2020, 12, 26, 12, 0, 0, 0, time.UTC), false},
+		{"de", time.Date(2020, 7, 14, 12, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, d := range testData {
+		metadata["holidayCalendarKey"] = d.key
+		s, err := NewCronScaler(&ScalerConfig{TriggerMetadata: metadata, Namespace: "test", KubeClient: kubeClient})
+		if err != nil {
+			t.Fatal("Could not create scaler:", err)
+		}
+		_, active, err := s.(*cronScaler).getDesiredReplicas(context.TODO(), d.now)
+		assert.NoError(t, err)
+		assert.Equal(t, d.active, active, d.now)
+	}
+
+	metadata["holidayCalendarKey"] = "it"
+	s, _ := NewCronScaler(&ScalerConfig{TriggerMetadata: metadata, Namespace: "test", KubeClient: kubeClient})
+	_, err := s.IsActive(context.TODO())
+	assert.Error(t, err)
+
+	delete(metadata, "holidayCalendarKey")
+	_, err = NewCronScaler(&ScalerConfig{TriggerMetadata: metadata, Namespace: "test"})
+	assert.Error(t, err)
+}
+
+func TestCronHolidayCalendarIsCachedForPollingInterval(t *testing.T) {
+	calendar := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "holidays", Namespace: "test"},
+		Data:       map[string]string{"de": "2020-12-25"},
+	}
+	kubeClient := fake.NewFakeClient(calendar)
+	metadata := map[string]string{"timezone": "Etc/UTC", "start": "0 8 * * *", "end": "0 18 * * *", "desiredReplicas": "2", "holidayCalendarConfigMap": "holidays"}
+	s, err := NewCronScaler(&ScalerConfig{TriggerMetadata: metadata, Namespace: "test", KubeClient: kubeClient, PollingInterval: time.Hour})
+	if err != nil {
+		t.Fatal("Could not create scaler:", err)
+	}
+	scaler := s.(*cronScaler)
+	now := time.Date(2020, 12, 26, 12, 0, 0, 0, time.UTC)
+
+	_, active, err := scaler.getDesiredReplicas(context.TODO(), now)
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	calendar.Data["de"] = "2020-12-25\n2020-12-26"
+	assert.NoError(t, kubeClient.Update(context.TODO(), calendar))
+
+	// the calendar read in this polling interval is reused
+	_, active, err = scaler.getDesiredReplicas(context.TODO(), now)
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	scaler.calendarReadTime = scaler.calendarReadTime.Add(-time.Hour)
+	_, active, err = scaler.getDesiredReplicas(context.TODO(), now)
+	assert.NoError(t, err)
+	assert.False(t, active)
+}
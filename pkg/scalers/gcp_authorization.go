@@ -0,0 +1,105 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	iamcredentials "google.golang.org/api/iamcredentials/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+type gcpAuthorizationMetadata struct {
+	GoogleApplicationCredentials string
+	podIdentityOwner             bool
+
+	// podIdentityProviderEnabled uses the application default credentials of the KEDA operator, ie. the GKE
+	// metadata server with workload identity, instead of GoogleApplicationCredentials
+	podIdentityProviderEnabled bool
+	// impersonateServiceAccount is the email of a service account, whose access tokens are generated
+	// with the application default credentials
+	impersonateServiceAccount string
+}
+
+func getGcpAuthorization(authParams, metadata, resolvedEnv map[string]string, podIdentity kedav1alpha1.PodIdentityProvider) (*gcpAuthorizationMetadata, error) {
+	meta := gcpAuthorizationMetadata{}
+
+	if podIdentity == kedav1alpha1.PodIdentityProviderGCP {
+		meta.podIdentityOwner = true
+		meta.podIdentityProviderEnabled = true
+
+		if val := authParams["impersonateServiceAccount"]; val != "" {
+			meta.impersonateServiceAccount = val
+		} else if val := metadata["impersonateServiceAccount"]; val != "" {
+			meta.impersonateServiceAccount = val
+		}
+		if meta.impersonateServiceAccount != "" && !strings.Contains(meta.impersonateServiceAccount, "@") {
+			return nil, fmt.Errorf("impersonateServiceAccount must be the email of a service account but is %s", meta.impersonateServiceAccount)
+		}
+		return &meta, nil
+	}
+
+	if metadata["identityOwner"] == "operator" {
+		meta.podIdentityOwner = false
+	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
+		meta.podIdentityOwner = true
+		if authParams["GoogleApplicationCredentials"] != "" {
+			meta.GoogleApplicationCredentials = authParams["GoogleApplicationCredentials"]
+		} else {
+			if metadata["credentialsFromEnv"] != "" {
+				meta.GoogleApplicationCredentials = resolvedEnv[metadata["credentialsFromEnv"]]
+			} else {
+				return nil, fmt.Errorf("GoogleApplicationCredentials not found")
+			}
+		}
+	}
+	return &meta, nil
+}
+
+// newStackDriverClientForAuth creates a stackdriver client with the pod identity or the credentials of the scaler
+func newStackDriverClientForAuth(ctx context.Context, auth gcpAuthorizationMetadata, endpoint string) (*StackDriverClient, error) {
+	if auth.podIdentityProviderEnabled {
+		return NewStackDriverClientPodIdentity(ctx, auth.impersonateServiceAccount, endpoint)
+	}
+	return NewStackDriverClient(ctx, auth.GoogleApplicationCredentials, endpoint)
+}
+
+// gcpImpersonatedTokenSource generates access tokens of a service account with the IAM credentials API,
+// the caller needs the roles/iam.serviceAccountTokenCreator role on this service account
+type gcpImpersonatedTokenSource struct {
+	service        *iamcredentials.Service
+	serviceAccount string
+	scopes         []string
+}
+
+// newGcpImpersonatedTokenSource returns a token source, which generates a new access token when the previous one expires
+func newGcpImpersonatedTokenSource(service *iamcredentials.Service, serviceAccount string, scopes []string) oauth2.TokenSource {
+	return oauth2.ReuseTokenSource(nil, gcpImpersonatedTokenSource{
+		service:        service,
+		serviceAccount: serviceAccount,
+		scopes:         scopes,
+	})
+}
+
+func (ts gcpImpersonatedTokenSource) Token() (*oauth2.Token, error) {
+	name := "projects/-/serviceAccounts/" + ts.serviceAccount
+	resp, err := ts.service.Projects.ServiceAccounts.GenerateAccessToken(name, &iamcredentials.GenerateAccessTokenRequest{
+		Scope: ts.scopes,
+	}).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error impersonating service account %s: %s", ts.serviceAccount, err)
+	}
+
+	expiry, err := time.Parse(time.RFC3339, resp.ExpireTime)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing expiry time of the access token of %s: %s", ts.serviceAccount, err)
+	}
+	return &oauth2.Token{
+		AccessToken: resp.AccessToken,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
+}
@@ -0,0 +1,98 @@
+package scalers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	iamcredentials "google.golang.org/api/iamcredentials/v1"
+	"google.golang.org/api/option"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+type parseGcpAuthorizationTestData struct {
+	authParams  map[string]string
+	metadata    map[string]string
+	podIdentity kedav1alpha1.PodIdentityProvider
+	isError     bool
+	comment     string
+}
+
+var testGcpAuthorizationData = []parseGcpAuthorizationTestData{
+	{map[string]string{}, map[string]string{}, kedav1alpha1.PodIdentityProviderGCP, false, "workload identity"},
+	{map[string]string{"impersonateServiceAccount": "keda@my-project.iam.gserviceaccount.com"}, map[string]string{}, kedav1alpha1.PodIdentityProviderGCP, false, "impersonation from auth params"},
+	{map[string]string{}, map[string]string{"impersonateServiceAccount": "keda@my-project.iam.gserviceaccount.com"}, kedav1alpha1.PodIdentityProviderGCP, false, "impersonation from metadata"},
+	{map[string]string{"impersonateServiceAccount": "keda"}, map[string]string{}, kedav1alpha1.PodIdentityProviderGCP, true, "impersonation without email"},
+	{map[string]string{}, map[string]string{}, "", true, "missing credentials"},
+	{map[string]string{"GoogleApplicationCredentials": "{}"}, map[string]string{}, "", false, "credentials"},
+}
+
+func TestParseGcpAuthorization(t *testing.T) {
+	for _, testData := range testGcpAuthorizationData {
+		_, err := getGcpAuthorization(testData.authParams, testData.metadata, map[string]string{}, testData.podIdentity)
+		if err != nil && !testData.isError {
+			t.Errorf("%s: Expected success but got error %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: Expected error but got success", testData.comment)
+		}
+	}
+}
+
+func TestPubSubParseMetadataPodIdentity(t *testing.T) {
+	meta, err := parsePubSubMetadata(&ScalerConfig{
+		AuthParams:      map[string]string{"impersonateServiceAccount": "keda@my-project.iam.gserviceaccount.com"},
+		TriggerMetadata: map[string]string{"subscriptionName": "mysubscription"},
+		PodIdentity:     kedav1alpha1.PodIdentityProviderGCP,
+	})
+	assert.NoError(t, err)
+	assert.True(t, meta.gcpAuthorization.podIdentityProviderEnabled)
+	assert.Equal(t, "", meta.gcpAuthorization.GoogleApplicationCredentials)
+	assert.Equal(t, "keda@my-project.iam.gserviceaccount.com", meta.gcpAuthorization.impersonateServiceAccount)
+}
+
+func TestGcpImpersonatedTokenSource(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/v1/projects/-/serviceAccounts/keda@my-project.iam.gserviceaccount.com:generateAccessToken", r.URL.Path)
+
+		var req iamcredentials.GenerateAccessTokenRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, []string{"https://www.googleapis.com/auth/monitoring.read"}, req.Scope)
+
+		_, _ = w.Write([]byte(`{"accessToken": "impersonated-token", "expireTime": "2100-01-01T00:00:00Z"}`))
+	}))
+	defer server.Close()
+
+	service, err := iamcredentials.NewService(context.Background(), option.WithEndpoint(server.URL+"/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokenSource := newGcpImpersonatedTokenSource(service, "keda@my-project.iam.gserviceaccount.com", []string{"https://www.googleapis.com/auth/monitoring.read"})
+
+	token, err := tokenSource.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "impersonated-token", token.AccessToken)
+
+	// the token is reused until it expires
+	_, err = tokenSource.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestStackDriverClientPodIdentityRejectsCustomEndpoint(t *testing.T) {
+	// the token of the operator must never be sent to an endpoint chosen in the trigger metadata
+	_, err := NewStackDriverClientPodIdentity(context.Background(), "", "monitoring.example.com:443")
+	assert.Error(t, err)
+
+	_, err = parseStackdriverMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "endpoint": "https://monitoring.example.com"},
+		PodIdentity:     kedav1alpha1.PodIdentityProviderGCP,
+	})
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -16,24 +17,32 @@ import (
 )
 
 const (
-	defaultTargetSubscriptionSize = 5
-	pubSubStackDriverMetricName   = "pubsub.googleapis.com/subscription/num_undelivered_messages"
+	defaultTargetSubscriptionSize            = 5
+	pubSubStackDriverMetricName              = "pubsub.googleapis.com/subscription/num_undelivered_messages"
+	pubSubStackDriverOldestMessageMetricName = "pubsub.googleapis.com/subscription/oldest_unacked_message_age"
 )
 
-type gcpAuthorizationMetadata struct {
-	GoogleApplicationCredentials string
-	podIdentityOwner             bool
-}
+type pubSubMode string
+
+const (
+	pubSubModeSubscriptionSize        pubSubMode = "SubscriptionSize"
+	pubSubModeOldestUnackedMessageAge pubSubMode = "OldestUnackedMessageAge"
+)
 
 type pubsubScaler struct {
-	client   *StackDriverClient
-	metadata *pubsubMetadata
+	// clientLock guards client, which is created on first use and reset by Close
+	clientLock sync.Mutex
+	client     *StackDriverClient
+	metadata   *pubsubMetadata
 }
 
 type pubsubMetadata struct {
-	targetSubscriptionSize int
-	subscriptionName       string
-	gcpAuthorization       gcpAuthorizationMetadata
+	mode             pubSubMode // either SubscriptionSize or OldestUnackedMessageAge
+	value            float64    // target number of messages or age of the oldest message in seconds per replica
+	activationValue  float64
+	subscriptionName string
+	endpoint         string
+	gcpAuthorization gcpAuthorizationMetadata
 }
 
 var gcpPubSubLog = logf.Log.WithName("gcp_pub_sub_scaler")
@@ -52,15 +61,9 @@ func NewPubSubScaler(config *ScalerConfig) (Scaler, error) {
 
 func parsePubSubMetadata(config *ScalerConfig) (*pubsubMetadata, error) {
 	meta := pubsubMetadata{}
-	meta.targetSubscriptionSize = defaultTargetSubscriptionSize
 
-	if val, ok := config.TriggerMetadata["subscriptionSize"]; ok {
-		subscriptionSize, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("subscription Size parsing error %s", err.Error())
-		}
-
-		meta.targetSubscriptionSize = subscriptionSize
+	if err := parsePubSubModeAndValue(config, &meta); err != nil {
+		return nil, err
 	}
 
 	if val, ok := config.TriggerMetadata["subscriptionName"]; ok {
@@ -73,27 +76,90 @@ func parsePubSubMetadata(config *ScalerConfig) (*pubsubMetadata, error) {
 		return nil, fmt.Errorf("no subscription name given")
 	}
 
-	auth, err := getGcpAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv)
+	meta.endpoint = config.TriggerMetadata["endpoint"]
+	if err := validateStackDriverEndpoint(meta.endpoint); err != nil {
+		return nil, err
+	}
+
+	auth, err := getGcpAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
 	if err != nil {
 		return nil, err
 	}
+
 	meta.gcpAuthorization = *auth
 	return &meta, nil
 }
 
+// parsePubSubModeAndValue resolves the mode and its target value, subscriptionSize is still accepted
+// as the target of the SubscriptionSize mode
+func parsePubSubModeAndValue(config *ScalerConfig, meta *pubsubMetadata) error {
+	mode, modeGiven := config.TriggerMetadata["mode"]
+	value, valueGiven := config.TriggerMetadata["value"]
+	subscriptionSize, subscriptionSizeGiven := config.TriggerMetadata["subscriptionSize"]
+
+	switch {
+	case modeGiven && subscriptionSizeGiven:
+		return fmt.Errorf("subscriptionSize is deprecated and must not be used together with mode, use value instead")
+	case modeGiven:
+		switch pubSubMode(mode) {
+		case pubSubModeSubscriptionSize, pubSubModeOldestUnackedMessageAge:
+			meta.mode = pubSubMode(mode)
+		default:
+			return fmt.Errorf("the mode has to be either `%s` or `%s` but is `%s`", pubSubModeSubscriptionSize, pubSubModeOldestUnackedMessageAge, mode)
+		}
+		if !valueGiven {
+			return fmt.Errorf("no value given for mode %s", mode)
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("value parsing error %s", err.Error())
+		}
+		meta.value = v
+	case valueGiven:
+		return fmt.Errorf("value must be used together with mode")
+	case subscriptionSizeGiven:
+		v, err := strconv.Atoi(subscriptionSize)
+		if err != nil {
+			return fmt.Errorf("subscription Size parsing error %s", err.Error())
+		}
+		meta.mode = pubSubModeSubscriptionSize
+		meta.value = float64(v)
+	default:
+		meta.mode = pubSubModeSubscriptionSize
+		meta.value = defaultTargetSubscriptionSize
+	}
+
+	activation, err := config.GetActivationThreshold("activationValue", 0)
+	if err != nil {
+		return err
+	}
+	if _, ok := config.TriggerMetadata["activationValue"]; !ok && meta.mode == pubSubModeSubscriptionSize {
+		// activationSubscriptionSize precedes activationThreshold for compatibility
+		if activation, err = config.GetActivationThreshold("activationSubscriptionSize", 0); err != nil {
+			return err
+		}
+	}
+	meta.activationValue = activation
+
+	return nil
+}
+
 // IsActive checks if there are any messages in the subscription
 func (s *pubsubScaler) IsActive(ctx context.Context) (bool, error) {
-	size, err := s.GetSubscriptionSize(ctx)
+	value, err := s.getMetrics(ctx)
 
 	if err != nil {
 		gcpPubSubLog.Error(err, "error getting Active Status")
 		return false, err
 	}
 
-	return size > 0, nil
+	return value > s.metadata.activationValue, nil
 }
 
 func (s *pubsubScaler) Close() error {
+	s.clientLock.Lock()
+	defer s.clientLock.Unlock()
+
 	if s.client != nil {
 		err := s.client.metricsClient.Close()
 		s.client = nil
@@ -107,16 +173,21 @@ func (s *pubsubScaler) Close() error {
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *pubsubScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
-	// Construct the target subscription size as a quantity
-	targetSubscriptionSizeQty := resource.NewQuantity(int64(s.metadata.targetSubscriptionSize), resource.DecimalSI)
+	// Construct the target value as a quantity
+	targetValueQty := resource.NewMilliQuantity(int64(s.metadata.value*1000), resource.DecimalSI)
+
+	metricName := fmt.Sprintf("%s-%s", "gcp", s.metadata.subscriptionName)
+	if s.metadata.mode == pubSubModeOldestUnackedMessageAge {
+		metricName += "-oldest-unacked-message-age"
+	}
 
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: kedautil.NormalizeString(fmt.Sprintf("%s-%s", "gcp", s.metadata.subscriptionName)),
+			Name: kedautil.NormalizeString(metricName),
 		},
 		Target: v2beta2.MetricTarget{
 			Type:         v2beta2.AverageValueMetricType,
-			AverageValue: targetSubscriptionSizeQty,
+			AverageValue: targetValueQty,
 		},
 	}
 
@@ -129,55 +200,52 @@ func (s *pubsubScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
 	return []v2beta2.MetricSpec{metricSpec}
 }
 
-// GetMetrics connects to Stack Driver and finds the size of the pub sub subscription
+// GetMetrics connects to Stack Driver and finds the size of the pub sub subscription or the age of its oldest message
 func (s *pubsubScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	size, err := s.GetSubscriptionSize(ctx)
+	value, err := s.getMetrics(ctx)
 
 	if err != nil {
-		gcpPubSubLog.Error(err, "error getting subscription size")
+		gcpPubSubLog.Error(err, "error getting subscription metric", "mode", s.metadata.mode)
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(size, resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
-// GetSubscriptionSize gets the number of messages in a subscription by calling the
-// Stackdriver api
-func (s *pubsubScaler) GetSubscriptionSize(ctx context.Context) (int64, error) {
-	if s.client == nil {
-		client, err := NewStackDriverClient(ctx, s.metadata.gcpAuthorization.GoogleApplicationCredentials)
-		if err != nil {
-			return -1, err
-		}
-		s.client = client
+// getMetrics gets the number of messages in a subscription, or the age of its oldest unacknowledged message
+// in seconds, by calling the Stackdriver api
+func (s *pubsubScaler) getMetrics(ctx context.Context) (float64, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return -1, err
 	}
 
-	filter := `metric.type="` + pubSubStackDriverMetricName + `" AND resource.labels.subscription_id="` + s.metadata.subscriptionName + `"`
+	metricType := pubSubStackDriverMetricName
+	if s.metadata.mode == pubSubModeOldestUnackedMessageAge {
+		metricType = pubSubStackDriverOldestMessageMetricName
+	}
+	filter := `metric.type="` + metricType + `" AND resource.labels.subscription_id="` + s.metadata.subscriptionName + `"`
 
-	return s.client.GetMetrics(ctx, filter)
+	return client.GetMetrics(ctx, filter, "", nil)
 }
 
-func getGcpAuthorization(authParams, metadata, resolvedEnv map[string]string) (*gcpAuthorizationMetadata, error) {
-	meta := gcpAuthorizationMetadata{}
-	if metadata["identityOwner"] == "operator" {
-		meta.podIdentityOwner = false
-	} else if metadata["identityOwner"] == "" || metadata["identityOwner"] == "pod" {
-		meta.podIdentityOwner = true
-		if authParams["GoogleApplicationCredentials"] != "" {
-			meta.GoogleApplicationCredentials = authParams["GoogleApplicationCredentials"]
-		} else {
-			if metadata["credentialsFromEnv"] != "" {
-				meta.GoogleApplicationCredentials = resolvedEnv[metadata["credentialsFromEnv"]]
-			} else {
-				return nil, fmt.Errorf("GoogleApplicationCredentials not found")
-			}
+// getClient returns the StackDriver client, which is created on the first call
+func (s *pubsubScaler) getClient(ctx context.Context) (*StackDriverClient, error) {
+	s.clientLock.Lock()
+	defer s.clientLock.Unlock()
+
+	if s.client == nil {
+		client, err := newStackDriverClientForAuth(ctx, s.metadata.gcpAuthorization, s.metadata.endpoint)
+		if err != nil {
+			return nil, err
 		}
+		s.client = client
 	}
-	return &meta, nil
+	return s.client, nil
 }
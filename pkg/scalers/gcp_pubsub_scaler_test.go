@@ -33,10 +33,21 @@ var testPubSubMetadata = []parsePubSubMetadataTestData{
 	{map[string]string{"GoogleApplicationCredentials": "Creds", "podIdentityOwner": ""}, map[string]string{"subscriptionName": "mysubscription", "subscriptionSize": "7"}, false},
 	// Credentials from AuthParams with empty creds
 	{map[string]string{"GoogleApplicationCredentials": "", "podIdentityOwner": ""}, map[string]string{"subscriptionName": "mysubscription", "subscriptionSize": "7"}, true},
+	// age of the oldest unacked message
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "OldestUnackedMessageAge", "value": "30", "credentialsFromEnv": "SAMPLE_CREDS"}, false},
+	// mode without value
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "OldestUnackedMessageAge", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// unknown mode
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "MessageRate", "value": "30", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// mode together with subscriptionSize
+	{nil, map[string]string{"subscriptionName": "mysubscription", "mode": "SubscriptionSize", "value": "7", "subscriptionSize": "7", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
+	// custom endpoint receiving the credentials
+	{nil, map[string]string{"subscriptionName": "mysubscription", "endpoint": "https://pubsub.example.com", "credentialsFromEnv": "SAMPLE_CREDS"}, true},
 }
 
 var gcpPubSubMetricIdentifiers = []gcpPubSubMetricIdentifier{
 	{&testPubSubMetadata[1], "gcp-mysubscription"},
+	{&testPubSubMetadata[7], "gcp-mysubscription-oldest-unacked-message-age"},
 }
 
 func TestPubSubParseMetadata(t *testing.T) {
@@ -57,7 +68,7 @@ func TestGcpPubSubGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockGcpPubSubScaler := pubsubScaler{nil, meta}
+		mockGcpPubSubScaler := pubsubScaler{metadata: meta}
 
 		metricSpec := mockGcpPubSubScaler.GetMetricSpecForScaling()
 		metricName := metricSpec[0].External.Metric.Name
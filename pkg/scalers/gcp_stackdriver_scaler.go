@@ -0,0 +1,254 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	defaultStackdriverAlignmentPeriodSeconds = 60
+)
+
+type stackdriverScaler struct {
+	// clientLock guards client, which is created on first use and reset by Close
+	clientLock sync.Mutex
+	client     *StackDriverClient
+	metadata   *stackdriverMetadata
+}
+
+type stackdriverMetadata struct {
+	projectID             string
+	filter                string
+	targetValue           float64
+	activationTargetValue float64
+	// aggregation is nil if neither an aligner nor a reducer is given
+	aggregation      *monitoringpb.Aggregation
+	endpoint         string
+	gcpAuthorization gcpAuthorizationMetadata
+}
+
+var gcpStackdriverLog = logf.Log.WithName("gcp_stackdriver_scaler")
+
+// NewStackdriverScaler creates a new stackdriverScaler
+func NewStackdriverScaler(config *ScalerConfig) (Scaler, error) {
+	meta, err := parseStackdriverMetadata(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Stackdriver metadata: %s", err)
+	}
+
+	return &stackdriverScaler{
+		metadata: meta,
+	}, nil
+}
+
+func parseStackdriverMetadata(config *ScalerConfig) (*stackdriverMetadata, error) {
+	meta := stackdriverMetadata{}
+
+	// projectId defaults to the project of the credentials
+	meta.projectID = config.TriggerMetadata["projectId"]
+
+	if val, ok := config.TriggerMetadata["filter"]; ok && val != "" {
+		meta.filter = val
+	} else {
+		return nil, fmt.Errorf("no filter given")
+	}
+
+	if val, ok := config.TriggerMetadata["targetValue"]; ok && val != "" {
+		targetValue, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("targetValue parsing error %s", err.Error())
+		}
+		meta.targetValue = targetValue
+	} else {
+		return nil, fmt.Errorf("no targetValue given")
+	}
+
+	activation, err := config.GetActivationThreshold("activationTargetValue", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationTargetValue = activation
+
+	aggregation, err := parseStackdriverAggregation(config.TriggerMetadata)
+	if err != nil {
+		return nil, err
+	}
+	meta.aggregation = aggregation
+
+	meta.endpoint = config.TriggerMetadata["endpoint"]
+	if err := validateStackDriverEndpoint(meta.endpoint); err != nil {
+		return nil, err
+	}
+
+	auth, err := getGcpAuthorization(config.AuthParams, config.TriggerMetadata, config.ResolvedEnv, config.PodIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.gcpAuthorization = *auth
+	return &meta, nil
+}
+
+// parseStackdriverAggregation parses the aligner (eg. mean, max, rate) applied to each time series over the alignment
+// period and the reducer (eg. sum, max) combining the series matching the filter
+func parseStackdriverAggregation(metadata map[string]string) (*monitoringpb.Aggregation, error) {
+	aligner, alignerGiven := metadata["alignmentAligner"]
+	reducer, reducerGiven := metadata["alignmentReducer"]
+	period, periodGiven := metadata["alignmentPeriodSeconds"]
+
+	if !alignerGiven && !reducerGiven {
+		if periodGiven {
+			return nil, fmt.Errorf("alignmentPeriodSeconds requires alignmentAligner")
+		}
+		return nil, nil
+	}
+
+	aggregation := &monitoringpb.Aggregation{}
+
+	if alignerGiven {
+		val, ok := monitoringpb.Aggregation_Aligner_value["ALIGN_"+strings.ToUpper(aligner)]
+		if !ok {
+			return nil, fmt.Errorf("unknown alignmentAligner %s", aligner)
+		}
+		aggregation.PerSeriesAligner = monitoringpb.Aggregation_Aligner(val)
+	}
+
+	if reducerGiven {
+		val, ok := monitoringpb.Aggregation_Reducer_value["REDUCE_"+strings.ToUpper(reducer)]
+		if !ok {
+			return nil, fmt.Errorf("unknown alignmentReducer %s", reducer)
+		}
+		aggregation.CrossSeriesReducer = monitoringpb.Aggregation_Reducer(val)
+		if aggregation.PerSeriesAligner == monitoringpb.Aggregation_ALIGN_NONE {
+			return nil, fmt.Errorf("alignmentReducer requires alignmentAligner")
+		}
+	}
+
+	periodSeconds := int64(defaultStackdriverAlignmentPeriodSeconds)
+	if periodGiven {
+		v, err := strconv.ParseInt(period, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("alignmentPeriodSeconds parsing error %s", err.Error())
+		}
+		if v < 60 {
+			return nil, fmt.Errorf("alignmentPeriodSeconds must be at least 60")
+		}
+		periodSeconds = v
+	}
+	aggregation.AlignmentPeriod = &duration.Duration{Seconds: periodSeconds}
+
+	return aggregation, nil
+}
+
+// IsActive checks if the value of the metric is above the activation target value
+func (s *stackdriverScaler) IsActive(ctx context.Context) (bool, error) {
+	value, err := s.getMetrics(ctx)
+
+	if err != nil {
+		gcpStackdriverLog.Error(err, "error getting Active Status")
+		return false, err
+	}
+
+	return value > s.metadata.activationTargetValue, nil
+}
+
+func (s *stackdriverScaler) Close() error {
+	s.clientLock.Lock()
+	defer s.clientLock.Unlock()
+
+	if s.client != nil {
+		err := s.client.metricsClient.Close()
+		s.client = nil
+		if err != nil {
+			gcpStackdriverLog.Error(err, "error closing StackDriver client")
+		}
+	}
+
+	return nil
+}
+
+// getMetricName returns a name made from a hash of the project and the filter, which are too long for a metric name
+func (s *stackdriverScaler) getMetricName() string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(s.metadata.projectID))
+	_, _ = hash.Write([]byte(s.metadata.filter))
+	return fmt.Sprintf("%s-%x", "gcp-stackdriver", hash.Sum32())
+}
+
+// GetMetricSpecForScaling returns the metric spec for the HPA
+func (s *stackdriverScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
+	targetValueQty := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
+
+	externalMetric := &v2beta2.ExternalMetricSource{
+		Metric: v2beta2.MetricIdentifier{
+			Name: s.getMetricName(),
+		},
+		Target: v2beta2.MetricTarget{
+			Type:         v2beta2.AverageValueMetricType,
+			AverageValue: targetValueQty,
+		},
+	}
+
+	// Create the metric spec for the HPA
+	metricSpec := v2beta2.MetricSpec{
+		External: externalMetric,
+		Type:     externalMetricType,
+	}
+
+	return []v2beta2.MetricSpec{metricSpec}
+}
+
+// GetMetrics connects to Stack Driver and returns the latest value of the time series matching the filter
+func (s *stackdriverScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	value, err := s.getMetrics(ctx)
+
+	if err != nil {
+		gcpStackdriverLog.Error(err, "error getting metric value")
+		return []external_metrics.ExternalMetricValue{}, err
+	}
+
+	metric := external_metrics.ExternalMetricValue{
+		MetricName: metricName,
+		Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
+		Timestamp:  metav1.Now(),
+	}
+
+	return append([]external_metrics.ExternalMetricValue{}, metric), nil
+}
+
+func (s *stackdriverScaler) getMetrics(ctx context.Context) (float64, error) {
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	return client.GetMetrics(ctx, s.metadata.filter, s.metadata.projectID, s.metadata.aggregation)
+}
+
+// getClient returns the StackDriver client, which is created on the first call
+func (s *stackdriverScaler) getClient(ctx context.Context) (*StackDriverClient, error) {
+	s.clientLock.Lock()
+	defer s.clientLock.Unlock()
+
+	if s.client == nil {
+		client, err := newStackDriverClientForAuth(ctx, s.metadata.gcpAuthorization, s.metadata.endpoint)
+		if err != nil {
+			return nil, err
+		}
+		s.client = client
+	}
+	return s.client, nil
+}
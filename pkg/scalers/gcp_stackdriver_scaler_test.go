@@ -0,0 +1,128 @@
+package scalers
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/grpc"
+)
+
+var testStackdriverResolvedEnv = map[string]string{
+	"SAMPLE_CREDS": `{"project_id": "my-project"}`,
+}
+
+type parseStackdriverMetadataTestData struct {
+	metadata map[string]string
+	isError  bool
+	comment  string
+}
+
+var testStackdriverMetadata = []parseStackdriverMetadataTestData{
+	{map[string]string{}, true, "empty metadata"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "credentialsFromEnv": "SAMPLE_CREDS"}, false, "properly formed"},
+	{map[string]string{"projectId": "other-project", "filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "1.5", "activationTargetValue": "0.5", "credentialsFromEnv": "SAMPLE_CREDS"}, false, "project and activation"},
+	{map[string]string{"targetValue": "100", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "missing filter"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "credentialsFromEnv": "SAMPLE_CREDS"}, true, "missing targetValue"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "a", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "malformed targetValue"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100"}, true, "missing credentials"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "alignmentAligner": "rate", "alignmentReducer": "sum", "alignmentPeriodSeconds": "120", "credentialsFromEnv": "SAMPLE_CREDS"}, false, "aggregation"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "alignmentAligner": "unknown", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "unknown aligner"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "alignmentReducer": "sum", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "reducer without aligner"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "alignmentAligner": "mean", "alignmentPeriodSeconds": "30", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "alignment period too short"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "endpoint": "http://localhost:8085", "credentialsFromEnv": "SAMPLE_CREDS"}, false, "emulator endpoint"},
+	{map[string]string{"filter": `metric.type="storage.googleapis.com/network/received_bytes_count"`, "targetValue": "100", "endpoint": "monitoring.example.com:443", "credentialsFromEnv": "SAMPLE_CREDS"}, true, "authenticated custom endpoint"},
+}
+
+func TestStackdriverParseMetadata(t *testing.T) {
+	for _, testData := range testStackdriverMetadata {
+		_, err := parseStackdriverMetadata(&ScalerConfig{AuthParams: map[string]string{}, TriggerMetadata: testData.metadata, ResolvedEnv: testStackdriverResolvedEnv})
+		if err != nil && !testData.isError {
+			t.Errorf("%s: Expected success but got error %s", testData.comment, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("%s: Expected error but got success", testData.comment)
+		}
+	}
+}
+
+func TestStackdriverParseAggregation(t *testing.T) {
+	meta, err := parseStackdriverMetadata(&ScalerConfig{AuthParams: map[string]string{}, TriggerMetadata: testStackdriverMetadata[7].metadata, ResolvedEnv: testStackdriverResolvedEnv})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	assert.Equal(t, monitoringpb.Aggregation_ALIGN_RATE, meta.aggregation.PerSeriesAligner)
+	assert.Equal(t, monitoringpb.Aggregation_REDUCE_SUM, meta.aggregation.CrossSeriesReducer)
+	assert.Equal(t, int64(120), meta.aggregation.AlignmentPeriod.Seconds)
+
+	meta, err = parseStackdriverMetadata(&ScalerConfig{AuthParams: map[string]string{}, TriggerMetadata: testStackdriverMetadata[1].metadata, ResolvedEnv: testStackdriverResolvedEnv})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	assert.Nil(t, meta.aggregation)
+}
+
+func TestStackdriverGetMetricSpecForScaling(t *testing.T) {
+	meta, err := parseStackdriverMetadata(&ScalerConfig{AuthParams: map[string]string{}, TriggerMetadata: testStackdriverMetadata[2].metadata, ResolvedEnv: testStackdriverResolvedEnv})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	mockStackdriverScaler := stackdriverScaler{metadata: meta}
+
+	metricSpec := mockStackdriverScaler.GetMetricSpecForScaling()
+	assert.Regexp(t, "^gcp-stackdriver-[0-9a-f]{1,8}$", metricSpec[0].External.Metric.Name)
+	assert.Equal(t, "1500m", metricSpec[0].External.Target.AverageValue.String())
+}
+
+type testMetricServiceServer struct {
+	monitoringpb.UnimplementedMetricServiceServer
+	requests []*monitoringpb.ListTimeSeriesRequest
+	series   []*monitoringpb.TimeSeries
+}
+
+func (s *testMetricServiceServer) ListTimeSeries(ctx context.Context, req *monitoringpb.ListTimeSeriesRequest) (*monitoringpb.ListTimeSeriesResponse, error) {
+	s.requests = append(s.requests, req)
+	return &monitoringpb.ListTimeSeriesResponse{TimeSeries: s.series}, nil
+}
+
+func TestStackdriverGetMetricsFromEmulator(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	metricService := &testMetricServiceServer{
+		series: []*monitoringpb.TimeSeries{{
+			Points: []*monitoringpb.Point{
+				{Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: 2.5}}},
+				{Value: &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: 1}}},
+			},
+		}},
+	}
+	monitoringpb.RegisterMetricServiceServer(server, metricService)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	metadata := map[string]string{"endpoint": "http://" + listener.Addr().String()}
+	for k, v := range testStackdriverMetadata[7].metadata {
+		metadata[k] = v
+	}
+	scaler, err := NewStackdriverScaler(&ScalerConfig{AuthParams: map[string]string{}, TriggerMetadata: metadata, ResolvedEnv: testStackdriverResolvedEnv})
+	if err != nil {
+		t.Fatal("Could not create scaler:", err)
+	}
+	defer scaler.Close()
+
+	metrics, err := scaler.GetMetrics(context.Background(), "gcp-stackdriver", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "2500m", metrics[0].Value.String())
+
+	assert.Len(t, metricService.requests, 1)
+	req := metricService.requests[0]
+	assert.Equal(t, "projects/my-project", req.Name)
+	assert.Equal(t, testStackdriverMetadata[7].metadata["filter"], req.Filter)
+	assert.Equal(t, monitoringpb.Aggregation_REDUCE_SUM, req.Aggregation.CrossSeriesReducer)
+	assert.Equal(t, int64(120), req.Interval.EndTime.Seconds-req.Interval.StartTime.Seconds)
+}
@@ -41,6 +41,9 @@ type huaweiCloudeyeMetadata struct {
 	targetMetricValue float64
 	minMetricValue    float64
 
+	// activationTargetMetricValue defaults to minMetricValue
+	activationTargetMetricValue float64
+
 	metricCollectionTime int64
 	metricFilter         string
 	metricPeriod         string
@@ -136,6 +139,12 @@ func parseHuaweiCloudeyeMetadata(config *ScalerConfig) (*huaweiCloudeyeMetadata,
 		return nil, fmt.Errorf("min Metric Value not given")
 	}
 
+	activation, err := config.GetActivationThreshold("activationTargetMetricValue", meta.minMetricValue)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationTargetMetricValue = activation
+
 	if val, ok := config.TriggerMetadata["metricCollectionTime"]; ok && val != "" {
 		metricCollectionTime, err := strconv.Atoi(val)
 		if err != nil {
@@ -263,7 +272,7 @@ func (h *huaweiCloudeyeScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return val > h.metadata.minMetricValue, nil
+	return val > h.metadata.activationTargetMetricValue, nil
 }
 
 func (h *huaweiCloudeyeScaler) Close() error {
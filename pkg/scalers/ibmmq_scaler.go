@@ -34,13 +34,14 @@ type IBMMQScaler struct {
 
 // IBMMQMetadata Metadata used by KEDA to query IBM MQ queue depth and scale
 type IBMMQMetadata struct {
-	host             string
-	queueManager     string
-	queueName        string
-	username         string
-	password         string
-	targetQueueDepth int
-	tlsDisabled      bool
+	host                 string
+	queueManager         string
+	queueName            string
+	username             string
+	password             string
+	targetQueueDepth     int
+	activationQueueDepth float64
+	tlsDisabled          bool
 }
 
 // CommandResponse Full structured response from MQ admin REST query
@@ -137,6 +138,12 @@ func parseIBMMQMetadata(config *ScalerConfig) (*IBMMQMetadata, error) {
 		return nil, fmt.Errorf("no password given")
 	}
 
+	activation, err := config.GetActivationThreshold("activationQueueDepth", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationQueueDepth = activation
+
 	return &meta, nil
 }
 
@@ -146,7 +153,7 @@ func (s *IBMMQScaler) IsActive(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("error inspecting IBM MQ queue depth: %s", err)
 	}
-	return queueDepth > 0, nil
+	return float64(queueDepth) > s.metadata.activationQueueDepth, nil
 }
 
 // getQueueDepthViaHTTP returns the depth of the MQ Queue from the Admin endpoint
@@ -0,0 +1,60 @@
+package scalers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+// writeKafkaKerberosFiles writes the keytab and krb5.conf given in the TriggerAuthentication to temporary files,
+// sarama reads them from the file system whenever a connection to a broker is authenticated
+func writeKafkaKerberosFiles(metadata *kafkaMetadata) error {
+	if metadata.saslType != KafkaSASLTypeGSSAPI {
+		return nil
+	}
+
+	path, err := writeKafkaTempFile("keda-kafka-krb5-", metadata.kerberosConfig)
+	if err != nil {
+		return fmt.Errorf("error writing kerberos config: %s", err)
+	}
+	metadata.kerberosConfigPath = path
+
+	if metadata.keytab != "" {
+		path, err = writeKafkaTempFile("keda-kafka-keytab-", metadata.keytab)
+		if err != nil {
+			removeKafkaKerberosFiles(metadata)
+			return fmt.Errorf("error writing keytab: %s", err)
+		}
+		metadata.keytabPath = path
+	}
+
+	return nil
+}
+
+// removeKafkaKerberosFiles removes the temporary files written by writeKafkaKerberosFiles
+func removeKafkaKerberosFiles(metadata *kafkaMetadata) {
+	for _, path := range []string{metadata.kerberosConfigPath, metadata.keytabPath} {
+		if path == "" {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			kafkaLog.Error(err, "error removing kerberos file", "path", path)
+		}
+	}
+	metadata.kerberosConfigPath = ""
+	metadata.keytabPath = ""
+}
+
+func writeKafkaTempFile(prefix string, content string) (string, error) {
+	file, err := ioutil.TempFile("", prefix)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(content); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
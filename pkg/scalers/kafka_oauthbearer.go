@@ -0,0 +1,47 @@
+package scalers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const kafkaOAuthTokenTimeout = 10 * time.Second
+
+// kafkaOAuthTokenProvider implements sarama.AccessTokenProvider for SASL/OAUTHBEARER,
+// tokens are requested with the OAuth client credentials flow, cached and refreshed before they expire
+type kafkaOAuthTokenProvider struct {
+	tokenSource oauth2.TokenSource
+	extensions  map[string]string
+}
+
+func newKafkaOAuthTokenProvider(clientID string, clientSecret string, tokenURL string, scopes []string, extensions map[string]string) sarama.AccessTokenProvider {
+	config := clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       scopes,
+	}
+	// the token source keeps the context for all future token requests
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: kafkaOAuthTokenTimeout})
+
+	return &kafkaOAuthTokenProvider{
+		tokenSource: config.TokenSource(ctx),
+		extensions:  extensions,
+	}
+}
+
+// Token returns a valid access token, a new one is requested if the cached token has expired
+func (p *kafkaOAuthTokenProvider) Token() (*sarama.AccessToken, error) {
+	token, err := p.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("error requesting oauth token: %s", err)
+	}
+
+	return &sarama.AccessToken{Token: token.AccessToken, Extensions: p.extensions}, nil
+}
@@ -25,17 +25,33 @@ type kafkaScaler struct {
 }
 
 type kafkaMetadata struct {
-	bootstrapServers  []string
-	group             string
-	topic             string
-	lagThreshold      int64
-	offsetResetPolicy offsetResetPolicy
+	bootstrapServers       []string
+	group                  string
+	topic                  string
+	lagThreshold           int64
+	activationLagThreshold float64
+	offsetResetPolicy      offsetResetPolicy
+	allowIdleConsumers     bool
 
 	// SASL
 	saslType kafkaSaslType
 	username string
 	password string
 
+	// OAUTHBEARER
+	oauthTokenEndpointURI string
+	scopes                []string
+	oauthExtensions       map[string]string
+
+	// GSSAPI
+	realm               string
+	keytab              string
+	kerberosConfig      string
+	kerberosServiceName string
+	kerberosDisableFAST bool
+	keytabPath          string
+	kerberosConfigPath  string
+
 	// TLS
 	enableTLS bool
 	cert      string
@@ -58,6 +74,8 @@ const (
 	KafkaSASLTypePlaintext   kafkaSaslType = "plaintext"
 	KafkaSASLTypeSCRAMSHA256 kafkaSaslType = "scram_sha256"
 	KafkaSASLTypeSCRAMSHA512 kafkaSaslType = "scram_sha512"
+	KafkaSASLTypeOAuthbearer kafkaSaslType = "oauthbearer"
+	KafkaSASLTypeGSSAPI      kafkaSaslType = "gssapi"
 )
 
 const (
@@ -65,6 +83,7 @@ const (
 	kafkaMetricType          = "External"
 	defaultKafkaLagThreshold = 10
 	defaultOffsetResetPolicy = latest
+	defaultKerberosService   = "kafka"
 	invalidOffset            = -1
 )
 
@@ -77,8 +96,13 @@ func NewKafkaScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing kafka metadata: %s", err)
 	}
 
+	if err := writeKafkaKerberosFiles(&kafkaMetadata); err != nil {
+		return nil, err
+	}
+
 	client, admin, err := getKafkaClients(kafkaMetadata)
 	if err != nil {
+		removeKafkaKerberosFiles(&kafkaMetadata)
 		return nil, err
 	}
 
@@ -104,9 +128,7 @@ func parseKafkaMetadata(config *ScalerConfig) (kafkaMetadata, error) {
 	}
 	meta.group = config.TriggerMetadata["consumerGroup"]
 
-	if config.TriggerMetadata["topic"] == "" {
-		return meta, errors.New("no topic given")
-	}
+	// if no topic is given, the lag of all topics the consumer group has committed offsets for is used
 	meta.topic = config.TriggerMetadata["topic"]
 
 	meta.offsetResetPolicy = defaultOffsetResetPolicy
@@ -129,12 +151,28 @@ func parseKafkaMetadata(config *ScalerConfig) (kafkaMetadata, error) {
 		meta.lagThreshold = t
 	}
 
+	meta.allowIdleConsumers = false
+	if val, ok := config.TriggerMetadata["allowIdleConsumers"]; ok {
+		t, err := strconv.ParseBool(val)
+		if err != nil {
+			return meta, fmt.Errorf("error parsing allowIdleConsumers: %s", err)
+		}
+		meta.allowIdleConsumers = t
+	}
+
+	activation, err := config.GetActivationThreshold("activationLagThreshold", 0)
+	if err != nil {
+		return meta, err
+	}
+	meta.activationLagThreshold = activation
+
 	meta.saslType = KafkaSASLTypeNone
 	if val, ok := config.AuthParams["sasl"]; ok {
 		val = strings.TrimSpace(val)
 		mode := kafkaSaslType(val)
 
-		if mode == KafkaSASLTypePlaintext || mode == KafkaSASLTypeSCRAMSHA256 || mode == KafkaSASLTypeSCRAMSHA512 {
+		switch mode {
+		case KafkaSASLTypePlaintext, KafkaSASLTypeSCRAMSHA256, KafkaSASLTypeSCRAMSHA512:
 			if config.AuthParams["username"] == "" {
 				return meta, errors.New("no username given")
 			}
@@ -144,10 +182,18 @@ func parseKafkaMetadata(config *ScalerConfig) (kafkaMetadata, error) {
 				return meta, errors.New("no password given")
 			}
 			meta.password = strings.TrimSpace(config.AuthParams["password"])
-			meta.saslType = mode
-		} else {
+		case KafkaSASLTypeOAuthbearer:
+			if err := parseKafkaOAuthParams(config, &meta); err != nil {
+				return meta, err
+			}
+		case KafkaSASLTypeGSSAPI:
+			if err := parseKafkaKerberosParams(config, &meta); err != nil {
+				return meta, err
+			}
+		default:
 			return meta, fmt.Errorf("err SASL mode %s given", mode)
 		}
+		meta.saslType = mode
 	}
 
 	meta.enableTLS = false
@@ -175,28 +221,112 @@ func parseKafkaMetadata(config *ScalerConfig) (kafkaMetadata, error) {
 	return meta, nil
 }
 
+// parseKafkaOAuthParams parses the client credentials and the token endpoint of SASL/OAUTHBEARER,
+// username and password are used as the OAuth client id and secret
+func parseKafkaOAuthParams(config *ScalerConfig, meta *kafkaMetadata) error {
+	if config.AuthParams["username"] == "" {
+		return errors.New("no username given")
+	}
+	meta.username = strings.TrimSpace(config.AuthParams["username"])
+
+	if config.AuthParams["password"] == "" {
+		return errors.New("no password given")
+	}
+	meta.password = strings.TrimSpace(config.AuthParams["password"])
+
+	if config.AuthParams["oauthTokenEndpointUri"] == "" {
+		return errors.New("no oauth token endpoint uri given")
+	}
+	meta.oauthTokenEndpointURI = strings.TrimSpace(config.AuthParams["oauthTokenEndpointUri"])
+
+	meta.scopes = nil
+	for _, scope := range strings.Split(config.AuthParams["scopes"], ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			meta.scopes = append(meta.scopes, scope)
+		}
+	}
+
+	meta.oauthExtensions = map[string]string{}
+	if val := strings.TrimSpace(config.AuthParams["oauthExtensions"]); val != "" {
+		for _, extension := range strings.Split(val, ",") {
+			keyValue := strings.SplitN(extension, "=", 2)
+			if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) == "" {
+				return fmt.Errorf("err oauthExtensions %s given, expected key=value pairs", val)
+			}
+			meta.oauthExtensions[strings.TrimSpace(keyValue[0])] = strings.TrimSpace(keyValue[1])
+		}
+	}
+
+	return nil
+}
+
+// parseKafkaKerberosParams parses the kerberos principal and credentials of SASL/GSSAPI,
+// either keytab or password has to be given
+func parseKafkaKerberosParams(config *ScalerConfig, meta *kafkaMetadata) error {
+	if config.AuthParams["username"] == "" {
+		return errors.New("no username given")
+	}
+	meta.username = strings.TrimSpace(config.AuthParams["username"])
+
+	if config.AuthParams["realm"] == "" {
+		return errors.New("no realm given")
+	}
+	meta.realm = strings.TrimSpace(config.AuthParams["realm"])
+
+	if config.AuthParams["kerberosConfig"] == "" {
+		return errors.New("no kerberos config given")
+	}
+	meta.kerberosConfig = config.AuthParams["kerberosConfig"]
+
+	meta.keytab = config.AuthParams["keytab"]
+	meta.password = strings.TrimSpace(config.AuthParams["password"])
+	if meta.keytab == "" && meta.password == "" {
+		return errors.New("no keytab or password given")
+	}
+
+	meta.kerberosServiceName = defaultKerberosService
+	if val := strings.TrimSpace(config.AuthParams["kerberosServiceName"]); val != "" {
+		meta.kerberosServiceName = val
+	}
+
+	meta.kerberosDisableFAST = false
+	if val, ok := config.AuthParams["kerberosDisableFAST"]; ok {
+		t, err := strconv.ParseBool(strings.TrimSpace(val))
+		if err != nil {
+			return fmt.Errorf("error parsing kerberosDisableFAST: %s", err)
+		}
+		meta.kerberosDisableFAST = t
+	}
+
+	return nil
+}
+
 // IsActive determines if we need to scale from zero
 func (s *kafkaScaler) IsActive(ctx context.Context) (bool, error) {
-	partitions, err := s.getPartitions()
+	topicPartitions, err := s.getTopicPartitions()
 	if err != nil {
 		return false, err
 	}
 
-	offsets, err := s.getOffsets(partitions)
+	offsets, err := s.getOffsets(topicPartitions)
 	if err != nil {
 		return false, err
 	}
 
-	for _, partition := range partitions {
-		lag, err := s.getLagForPartition(partition, offsets)
-		if err != nil && lag == invalidOffset {
-			return true, nil
-		}
-		kafkaLog.V(1).Info(fmt.Sprintf("Group %s has a lag of %d for topic %s and partition %d\n", s.metadata.group, lag, s.metadata.topic, partition))
+	totalLag := int64(0)
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			lag, err := s.getLagForPartition(topic, partition, offsets)
+			if err != nil && lag == invalidOffset {
+				return true, nil
+			}
+			kafkaLog.V(1).Info(fmt.Sprintf("Group %s has a lag of %d for topic %s and partition %d\n", s.metadata.group, lag, topic, partition))
 
-		// Return as soon as a lag was detected for any partition
-		if lag > 0 {
-			return true, nil
+			// Return as soon as the total lag exceeds the activation threshold
+			totalLag += lag
+			if float64(totalLag) > s.metadata.activationLagThreshold {
+				return true, nil
+			}
 		}
 	}
 
@@ -236,6 +366,29 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 		config.Net.SASL.Mechanism = sarama.SASLTypeSCRAMSHA512
 	}
 
+	if metadata.saslType == KafkaSASLTypeOAuthbearer {
+		config.Net.SASL.Mechanism = sarama.SASLTypeOAuth
+		config.Net.SASL.TokenProvider = newKafkaOAuthTokenProvider(metadata.username, metadata.password, metadata.oauthTokenEndpointURI, metadata.scopes, metadata.oauthExtensions)
+	}
+
+	if metadata.saslType == KafkaSASLTypeGSSAPI {
+		config.Net.SASL.Mechanism = sarama.SASLTypeGSSAPI
+		config.Net.SASL.GSSAPI = sarama.GSSAPIConfig{
+			KerberosConfigPath: metadata.kerberosConfigPath,
+			ServiceName:        metadata.kerberosServiceName,
+			Username:           metadata.username,
+			Realm:              metadata.realm,
+			DisablePAFXFAST:    metadata.kerberosDisableFAST,
+		}
+		if metadata.keytabPath != "" {
+			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_KEYTAB_AUTH
+			config.Net.SASL.GSSAPI.KeyTabPath = metadata.keytabPath
+		} else {
+			config.Net.SASL.GSSAPI.AuthType = sarama.KRB5_USER_AUTH
+			config.Net.SASL.GSSAPI.Password = metadata.password
+		}
+	}
+
 	client, err := sarama.NewClient(metadata.bootstrapServers, config)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error creating kafka client: %s", err)
@@ -252,28 +405,52 @@ func getKafkaClients(metadata kafkaMetadata) (sarama.Client, sarama.ClusterAdmin
 	return client, admin, nil
 }
 
-func (s *kafkaScaler) getPartitions() ([]int32, error) {
-	topicsMetadata, err := s.admin.DescribeTopics([]string{s.metadata.topic})
+// getTopicPartitions returns the partitions of the configured topic or, if no topic is configured,
+// the partitions of all topics the consumer group has committed offsets for
+func (s *kafkaScaler) getTopicPartitions() (map[string][]int32, error) {
+	var topics []string
+	if s.metadata.topic != "" {
+		topics = []string{s.metadata.topic}
+	} else {
+		// offsets of all topics are returned if no partitions are requested
+		offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error listing consumer group offsets: %s", err)
+		}
+		for topic := range offsets.Blocks {
+			topics = append(topics, topic)
+		}
+		if len(topics) == 0 {
+			kafkaLog.V(1).Info(fmt.Sprintf("Group %s has no committed offsets for any topic", s.metadata.group))
+			return map[string][]int32{}, nil
+		}
+	}
+
+	topicsMetadata, err := s.admin.DescribeTopics(topics)
 	if err != nil {
 		return nil, fmt.Errorf("error describing topics: %s", err)
 	}
-	if len(topicsMetadata) != 1 {
-		return nil, fmt.Errorf("expected only 1 topic metadata, got %d", len(topicsMetadata))
+	if len(topicsMetadata) != len(topics) {
+		return nil, fmt.Errorf("expected %d topic metadata, got %d", len(topics), len(topicsMetadata))
 	}
 
-	partitionMetadata := topicsMetadata[0].Partitions
-	partitions := make([]int32, len(partitionMetadata))
-	for i, p := range partitionMetadata {
-		partitions[i] = p.ID
+	topicPartitions := make(map[string][]int32, len(topicsMetadata))
+	for _, topicMetadata := range topicsMetadata {
+		if topicMetadata.Err != sarama.ErrNoError {
+			return nil, fmt.Errorf("error describing topic %s: %s", topicMetadata.Name, topicMetadata.Err)
+		}
+		partitions := make([]int32, len(topicMetadata.Partitions))
+		for i, p := range topicMetadata.Partitions {
+			partitions[i] = p.ID
+		}
+		topicPartitions[topicMetadata.Name] = partitions
 	}
 
-	return partitions, nil
+	return topicPartitions, nil
 }
 
-func (s *kafkaScaler) getOffsets(partitions []int32) (*sarama.OffsetFetchResponse, error) {
-	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, map[string][]int32{
-		s.metadata.topic: partitions,
-	})
+func (s *kafkaScaler) getOffsets(topicPartitions map[string][]int32) (*sarama.OffsetFetchResponse, error) {
+	offsets, err := s.admin.ListConsumerGroupOffsets(s.metadata.group, topicPartitions)
 
 	if err != nil {
 		return nil, fmt.Errorf("error listing consumer group offsets: %s", err)
@@ -282,23 +459,23 @@ func (s *kafkaScaler) getOffsets(partitions []int32) (*sarama.OffsetFetchRespons
 	return offsets, nil
 }
 
-func (s *kafkaScaler) getLagForPartition(partition int32, offsets *sarama.OffsetFetchResponse) (int64, error) {
-	block := offsets.GetBlock(s.metadata.topic, partition)
+func (s *kafkaScaler) getLagForPartition(topic string, partition int32, offsets *sarama.OffsetFetchResponse) (int64, error) {
+	block := offsets.GetBlock(topic, partition)
 	if block == nil {
-		kafkaLog.Error(fmt.Errorf("error finding offset block for topic %s and partition %d", s.metadata.topic, partition), "")
-		return 0, fmt.Errorf("error finding offset block for topic %s and partition %d", s.metadata.topic, partition)
+		kafkaLog.Error(fmt.Errorf("error finding offset block for topic %s and partition %d", topic, partition), "")
+		return 0, fmt.Errorf("error finding offset block for topic %s and partition %d", topic, partition)
 	}
 	consumerOffset := block.Offset
-	latestOffset, err := s.client.GetOffset(s.metadata.topic, partition, sarama.OffsetNewest)
+	latestOffset, err := s.client.GetOffset(topic, partition, sarama.OffsetNewest)
 	if err != nil {
-		kafkaLog.Error(err, fmt.Sprintf("error finding latest offset for topic %s and partition %d\n", s.metadata.topic, partition))
-		return 0, fmt.Errorf("error finding latest offset for topic %s and partition %d", s.metadata.topic, partition)
+		kafkaLog.Error(err, fmt.Sprintf("error finding latest offset for topic %s and partition %d\n", topic, partition))
+		return 0, fmt.Errorf("error finding latest offset for topic %s and partition %d", topic, partition)
 	}
 
 	if consumerOffset == invalidOffset {
 		if s.metadata.offsetResetPolicy == latest {
-			kafkaLog.V(0).Info(fmt.Sprintf("invalid offset found for topic %s in group %s and partition %d, probably no offset is committed yet", s.metadata.topic, s.metadata.group, partition))
-			return invalidOffset, fmt.Errorf("invalid offset found for topic %s in group %s and partition %d, probably no offset is committed yet", s.metadata.topic, s.metadata.group, partition)
+			kafkaLog.V(0).Info(fmt.Sprintf("invalid offset found for topic %s in group %s and partition %d, probably no offset is committed yet", topic, s.metadata.group, partition))
+			return invalidOffset, fmt.Errorf("invalid offset found for topic %s in group %s and partition %d, probably no offset is committed yet", topic, s.metadata.group, partition)
 		}
 		return latestOffset, nil
 	}
@@ -309,6 +486,7 @@ func (s *kafkaScaler) getLagForPartition(partition int32, offsets *sarama.Offset
 func (s *kafkaScaler) Close() error {
 	// underlying client will also be closed on admin's Close() call
 	err := s.admin.Close()
+	removeKafkaKerberosFiles(&s.metadata)
 	if err != nil {
 		return err
 	}
@@ -320,7 +498,7 @@ func (s *kafkaScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
 	targetMetricValue := resource.NewQuantity(s.metadata.lagThreshold, resource.DecimalSI)
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s", "kafka", s.metadata.topic, s.metadata.group)),
+			Name: s.getMetricName(),
 		},
 		Target: v2beta2.MetricTarget{
 			Type:         v2beta2.AverageValueMetricType,
@@ -331,31 +509,39 @@ func (s *kafkaScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
 	return []v2beta2.MetricSpec{metricSpec}
 }
 
+func (s *kafkaScaler) getMetricName() string {
+	if s.metadata.topic == "" {
+		return kedautil.NormalizeString(fmt.Sprintf("%s-%s", "kafka", s.metadata.group))
+	}
+	return kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s", "kafka", s.metadata.topic, s.metadata.group))
+}
+
 //GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *kafkaScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	partitions, err := s.getPartitions()
+	topicPartitions, err := s.getTopicPartitions()
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 
-	offsets, err := s.getOffsets(partitions)
+	offsets, err := s.getOffsets(topicPartitions)
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 
 	totalLag := int64(0)
-	for _, partition := range partitions {
-		lag, _ := s.getLagForPartition(partition, offsets)
+	totalPartitions := int64(0)
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			lag, _ := s.getLagForPartition(topic, partition, offsets)
 
-		totalLag += lag
+			totalLag += lag
+		}
+		totalPartitions += int64(len(partitions))
 	}
 
-	kafkaLog.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, partitions %v, threshold %v", totalLag, len(partitions), s.metadata.lagThreshold))
+	kafkaLog.V(1).Info(fmt.Sprintf("Kafka scaler: Providing metrics based on totalLag %v, topics %v, partitions %v, threshold %v", totalLag, len(topicPartitions), totalPartitions, s.metadata.lagThreshold))
 
-	// don't scale out beyond the number of partitions
-	if (totalLag / s.metadata.lagThreshold) > int64(len(partitions)) {
-		totalLag = int64(len(partitions)) * s.metadata.lagThreshold
-	}
+	totalLag = s.capLagToPartitions(totalLag, totalPartitions)
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
@@ -365,3 +551,12 @@ func (s *kafkaScaler) GetMetrics(ctx context.Context, metricName string, metricS
 
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
+
+// capLagToPartitions limits the lag, so the HPA doesn't scale out beyond the number of partitions,
+// consumers above the partition count would be idle, unless allowIdleConsumers is set
+func (s *kafkaScaler) capLagToPartitions(totalLag int64, totalPartitions int64) int64 {
+	if !s.metadata.allowIdleConsumers && (totalLag/s.metadata.lagThreshold) > totalPartitions {
+		return totalPartitions * s.metadata.lagThreshold
+	}
+	return totalLag
+}
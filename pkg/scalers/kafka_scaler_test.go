@@ -1,6 +1,11 @@
 package scalers
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"reflect"
 	"testing"
 )
@@ -48,8 +53,8 @@ var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	{map[string]string{}, true, 0, nil, "", "", ""},
 	// failure, no consumer group
 	{map[string]string{"bootstrapServers": "foobar:9092"}, true, 1, []string{"foobar:9092"}, "", "", "latest"},
-	// failure, no topic
-	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group"}, true, 1, []string{"foobar:9092"}, "my-group", "", offsetResetPolicy("latest")},
+	// success, no topic, all topics of the consumer group are used
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group"}, false, 1, []string{"foobar:9092"}, "my-group", "", offsetResetPolicy("latest")},
 	// success
 	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", offsetResetPolicy("latest")},
 	// success, more brokers
@@ -60,6 +65,10 @@ var parseKafkaMetadataTestDataset = []parseKafkaMetadataTestData{
 	{map[string]string{"bootstrapServers": "foo:9092,bar:9092", "consumerGroup": "my-group", "topic": "my-topic", "offsetResetPolicy": "foo"}, true, 2, []string{"foo:9092", "bar:9092"}, "my-group", "my-topic", ""},
 	// success, offsetResetPolicy policy earliest
 	{map[string]string{"bootstrapServers": "foo:9092,bar:9092", "consumerGroup": "my-group", "topic": "my-topic", "offsetResetPolicy": "earliest"}, false, 2, []string{"foo:9092", "bar:9092"}, "my-group", "my-topic", offsetResetPolicy("earliest")},
+	// success, activationLagThreshold
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "activationLagThreshold": "10"}, false, 1, []string{"foobar:9092"}, "my-group", "my-topic", offsetResetPolicy("latest")},
+	// failure, activationLagThreshold invalid
+	{map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "activationLagThreshold": "foo"}, true, 1, []string{"foobar:9092"}, "my-group", "my-topic", ""},
 }
 
 var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
@@ -101,10 +110,33 @@ var parseKafkaAuthParamsTestDataset = []parseKafkaAuthParamsTestData{
 	{map[string]string{"sasl": "plaintext", "username": "admin", "password": "admin", "tls": "enable", "ca": "caaa", "key": "keey"}, true, false},
 	// failure, SASL + TLS, missing key
 	{map[string]string{"sasl": "plaintext", "username": "admin", "password": "admin", "tls": "enable", "ca": "caaa", "cert": "ceert"}, true, false},
+	// success, SASL OAUTHBEARER
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret", "oauthTokenEndpointUri": "https://example.com/token"}, false, false},
+	// success, SASL OAUTHBEARER with scopes and extensions
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret", "oauthTokenEndpointUri": "https://example.com/token", "scopes": "kafka, read", "oauthExtensions": "logicalCluster=lkc-1,identityPoolId=pool-1"}, false, false},
+	// failure, SASL OAUTHBEARER missing token endpoint
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret"}, true, false},
+	// failure, SASL OAUTHBEARER missing client secret
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "oauthTokenEndpointUri": "https://example.com/token"}, true, false},
+	// failure, SASL OAUTHBEARER invalid extensions
+	{map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret", "oauthTokenEndpointUri": "https://example.com/token", "oauthExtensions": "foo"}, true, false},
+	// success, SASL GSSAPI with keytab
+	{map[string]string{"sasl": "gssapi", "username": "keda", "realm": "EXAMPLE.COM", "keytab": "keytab", "kerberosConfig": "[libdefaults]"}, false, false},
+	// success, SASL GSSAPI with password and TLS
+	{map[string]string{"sasl": "gssapi", "username": "keda", "realm": "EXAMPLE.COM", "password": "admin", "kerberosConfig": "[libdefaults]", "kerberosDisableFAST": "true", "tls": "enable", "ca": "caaa"}, false, true},
+	// failure, SASL GSSAPI missing keytab and password
+	{map[string]string{"sasl": "gssapi", "username": "keda", "realm": "EXAMPLE.COM", "kerberosConfig": "[libdefaults]"}, true, false},
+	// failure, SASL GSSAPI missing realm
+	{map[string]string{"sasl": "gssapi", "username": "keda", "keytab": "keytab", "kerberosConfig": "[libdefaults]"}, true, false},
+	// failure, SASL GSSAPI missing kerberos config
+	{map[string]string{"sasl": "gssapi", "username": "keda", "realm": "EXAMPLE.COM", "keytab": "keytab"}, true, false},
+	// failure, SASL GSSAPI invalid kerberosDisableFAST
+	{map[string]string{"sasl": "gssapi", "username": "keda", "realm": "EXAMPLE.COM", "keytab": "keytab", "kerberosConfig": "[libdefaults]", "kerberosDisableFAST": "foo"}, true, false},
 }
 
 var kafkaMetricIdentifiers = []kafkaMetricIdentifier{
 	{&parseKafkaMetadataTestDataset[4], "kafka-my-topic-my-group"},
+	{&parseKafkaMetadataTestDataset[2], "kafka-my-group"},
 }
 
 func TestGetBrokers(t *testing.T) {
@@ -189,3 +221,117 @@ func TestKafkaGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestKafkaAllowIdleConsumers(t *testing.T) {
+	meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: validKafkaMetadata, AuthParams: validWithoutAuthParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if meta.allowIdleConsumers {
+		t.Error("Expected allowIdleConsumers to be false by default")
+	}
+
+	metadata := map[string]string{"bootstrapServers": "foobar:9092", "consumerGroup": "my-group", "topic": "my-topic", "allowIdleConsumers": "true"}
+	meta, err = parseKafkaMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: validWithoutAuthParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if !meta.allowIdleConsumers {
+		t.Error("Expected allowIdleConsumers to be true")
+	}
+
+	metadata["allowIdleConsumers"] = "notvalid"
+	if _, err = parseKafkaMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: validWithoutAuthParams}); err == nil {
+		t.Error("Expected error for invalid allowIdleConsumers but got success")
+	}
+}
+
+func TestKafkaCapLagToPartitions(t *testing.T) {
+	scaler := kafkaScaler{metadata: kafkaMetadata{lagThreshold: 10}}
+	if lag := scaler.capLagToPartitions(100, 3); lag != 30 {
+		t.Errorf("Expected lag to be capped at 30 but got %d", lag)
+	}
+	if lag := scaler.capLagToPartitions(25, 3); lag != 25 {
+		t.Errorf("Expected lag 25 but got %d", lag)
+	}
+
+	scaler.metadata.allowIdleConsumers = true
+	if lag := scaler.capLagToPartitions(100, 3); lag != 100 {
+		t.Errorf("Expected lag 100 with allowIdleConsumers but got %d", lag)
+	}
+}
+
+func TestKafkaOAuthParams(t *testing.T) {
+	authParams := map[string]string{"sasl": "oauthbearer", "username": "client", "password": "secret", "oauthTokenEndpointUri": "https://example.com/token", "scopes": "kafka, read", "oauthExtensions": "logicalCluster=lkc-1"}
+	meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: validKafkaMetadata, AuthParams: authParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if !reflect.DeepEqual(meta.scopes, []string{"kafka", "read"}) {
+		t.Errorf("Expected scopes [kafka read] but got %v", meta.scopes)
+	}
+	if !reflect.DeepEqual(meta.oauthExtensions, map[string]string{"logicalCluster": "lkc-1"}) {
+		t.Errorf("Expected extensions map[logicalCluster:lkc-1] but got %v", meta.oauthExtensions)
+	}
+}
+
+func TestKafkaOAuthTokenProvider(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("grant_type") != "client_credentials" || r.Form.Get("scope") != "kafka" {
+			t.Errorf("Unexpected token request %v", r.Form)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"token-1","token_type":"bearer","expires_in":3600}`)
+	}))
+	defer server.Close()
+
+	provider := newKafkaOAuthTokenProvider("client", "secret", server.URL, []string{"kafka"}, map[string]string{"logicalCluster": "lkc-1"})
+	for i := 0; i < 2; i++ {
+		token, err := provider.Token()
+		if err != nil {
+			t.Fatal("Expected token but got error:", err)
+		}
+		if token.Token != "token-1" || token.Extensions["logicalCluster"] != "lkc-1" {
+			t.Errorf("Unexpected token %v", token)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the token to be cached, but it was requested %d times", requests)
+	}
+}
+
+func TestKafkaKerberosFiles(t *testing.T) {
+	authParams := map[string]string{"sasl": "gssapi", "username": "keda", "realm": "EXAMPLE.COM", "keytab": "keytab-content", "kerberosConfig": "[libdefaults]"}
+	meta, err := parseKafkaMetadata(&ScalerConfig{TriggerMetadata: validKafkaMetadata, AuthParams: authParams})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if meta.kerberosServiceName != defaultKerberosService {
+		t.Errorf("Expected default kerberos service name but got %s", meta.kerberosServiceName)
+	}
+
+	if err := writeKafkaKerberosFiles(&meta); err != nil {
+		t.Fatal("Could not write kerberos files:", err)
+	}
+	keytabPath, kerberosConfigPath := meta.keytabPath, meta.kerberosConfigPath
+	content, err := ioutil.ReadFile(keytabPath)
+	if err != nil || string(content) != "keytab-content" {
+		t.Errorf("Expected keytab file with the keytab content, got %q (%v)", content, err)
+	}
+	content, err = ioutil.ReadFile(kerberosConfigPath)
+	if err != nil || string(content) != "[libdefaults]" {
+		t.Errorf("Expected krb5.conf file with the kerberos config, got %q (%v)", content, err)
+	}
+
+	removeKafkaKerberosFiles(&meta)
+	for _, path := range []string{keytabPath, kerberosConfigPath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", path)
+		}
+	}
+}
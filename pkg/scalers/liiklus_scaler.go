@@ -25,11 +25,12 @@ type liiklusScaler struct {
 }
 
 type liiklusMetadata struct {
-	lagThreshold int64
-	address      string
-	topic        string
-	group        string
-	groupVersion uint32
+	lagThreshold           int64
+	activationLagThreshold float64
+	address                string
+	topic                  string
+	group                  string
+	groupVersion           uint32
 }
 
 const (
@@ -110,7 +111,7 @@ func (s *liiklusScaler) IsActive(ctx context.Context) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	return lag > 0, nil
+	return float64(lag) > s.metadata.activationLagThreshold, nil
 }
 
 // getLag returns the total lag, as well as per-partition lag for this scaler. That is, the difference between the
@@ -158,6 +159,11 @@ func parseLiiklusMetadata(config *ScalerConfig) (*liiklusMetadata, error) {
 		lagThreshold = t
 	}
 
+	activationLagThreshold, err := config.GetActivationThreshold("activationLagThreshold", 0)
+	if err != nil {
+		return nil, err
+	}
+
 	groupVersion := uint32(0)
 	if val, ok := config.TriggerMetadata["groupVersion"]; ok {
 		t, err := strconv.ParseInt(val, 10, 32)
@@ -176,10 +182,11 @@ func parseLiiklusMetadata(config *ScalerConfig) (*liiklusMetadata, error) {
 	}
 
 	return &liiklusMetadata{
-		topic:        config.TriggerMetadata["topic"],
-		address:      config.TriggerMetadata["address"],
-		group:        config.TriggerMetadata["group"],
-		groupVersion: groupVersion,
-		lagThreshold: lagThreshold,
+		topic:                  config.TriggerMetadata["topic"],
+		address:                config.TriggerMetadata["address"],
+		group:                  config.TriggerMetadata["group"],
+		groupVersion:           groupVersion,
+		lagThreshold:           lagThreshold,
+		activationLagThreshold: activationLagThreshold,
 	}, nil
 }
@@ -0,0 +1,325 @@
+package scalers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/tidwall/gjson"
+	"sigs.k8s.io/yaml"
+)
+
+type metricsAPIFormat string
+
+const (
+	jsonFormat       metricsAPIFormat = "json"
+	yamlFormat       metricsAPIFormat = "yaml"
+	xmlFormat        metricsAPIFormat = "xml"
+	prometheusFormat metricsAPIFormat = "prometheus"
+	plainTextFormat  metricsAPIFormat = "plain"
+)
+
+type metricsAPIAggregation string
+
+const (
+	// noAggregation requires valueLocation to select a single value
+	noAggregation  metricsAPIAggregation = ""
+	sumAggregation metricsAPIAggregation = "sum"
+	maxAggregation metricsAPIAggregation = "max"
+	minAggregation metricsAPIAggregation = "min"
+	avgAggregation metricsAPIAggregation = "avg"
+)
+
+var (
+	promSelectorRegex      = regexp.MustCompile(`^\s*([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(?:\{(.*)\})?\s*$`)
+	promLabelMatchersRegex = regexp.MustCompile(`^\s*(?:[a-zA-Z_][a-zA-Z0-9_]*\s*=\s*"(?:[^"\\]|\\.)*"\s*(?:,\s*|$))*$`)
+	promLabelMatcherRegex  = regexp.MustCompile(`([a-zA-Z_][a-zA-Z0-9_]*)\s*=\s*"((?:[^"\\]|\\.)*)"`)
+)
+
+func parseMetricsAPIFormat(value string) (metricsAPIFormat, error) {
+	switch format := metricsAPIFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case jsonFormat, yamlFormat, xmlFormat, prometheusFormat, plainTextFormat:
+		return format, nil
+	default:
+		return "", fmt.Errorf("format must be one of %s, %s, %s, %s or %s but is %s", jsonFormat, yamlFormat, xmlFormat, prometheusFormat, plainTextFormat, value)
+	}
+}
+
+func parseMetricsAPIAggregation(value string) (metricsAPIAggregation, error) {
+	switch aggregation := metricsAPIAggregation(strings.ToLower(strings.TrimSpace(value))); aggregation {
+	case sumAggregation, maxAggregation, minAggregation, avgAggregation:
+		return aggregation, nil
+	default:
+		return "", fmt.Errorf("aggregation must be one of %s, %s, %s or %s but is %s", sumAggregation, maxAggregation, minAggregation, avgAggregation, value)
+	}
+}
+
+// GetValueFromResponse uses provided valueLocation to access the numeric value in provided body of the given format,
+// multiple values selected by valueLocation are combined using aggregation
+func GetValueFromResponse(body []byte, valueLocation string, format metricsAPIFormat, aggregation metricsAPIAggregation) (float64, error) {
+	var values []float64
+	var err error
+
+	switch format {
+	case jsonFormat:
+		values, err = getValuesFromJSON(body, valueLocation)
+	case yamlFormat:
+		var jsonBody []byte
+		if jsonBody, err = yaml.YAMLToJSON(body); err != nil {
+			return 0, fmt.Errorf("error parsing yaml: %s", err)
+		}
+		values, err = getValuesFromJSON(jsonBody, valueLocation)
+	case xmlFormat:
+		values, err = getValuesFromXML(body, valueLocation)
+	case prometheusFormat:
+		values, err = getValuesFromPrometheus(body, valueLocation)
+	case plainTextFormat:
+		var v float64
+		if v, err = strconv.ParseFloat(strings.TrimSpace(string(body)), 64); err != nil {
+			return 0, fmt.Errorf("response must be a single number: %s", err)
+		}
+		values = []float64{v}
+	default:
+		return 0, fmt.Errorf("unsupported format %s", format)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return aggregateMetricsAPIValues(values, valueLocation, aggregation)
+}
+
+// aggregateMetricsAPIValues combines the values selected by valueLocation, an empty selection, eg. an empty list
+// of items, is 0 when an aggregation is set
+func aggregateMetricsAPIValues(values []float64, valueLocation string, aggregation metricsAPIAggregation) (float64, error) {
+	if aggregation == noAggregation {
+		if len(values) == 0 {
+			return 0, fmt.Errorf("valueLocation %s did not select any value", valueLocation)
+		}
+		if len(values) > 1 {
+			return 0, fmt.Errorf("valueLocation %s selected %d values, set aggregation to combine them", valueLocation, len(values))
+		}
+		return values[0], nil
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	result := values[0]
+	for _, v := range values[1:] {
+		switch aggregation {
+		case sumAggregation, avgAggregation:
+			result += v
+		case maxAggregation:
+			result = math.Max(result, v)
+		case minAggregation:
+			result = math.Min(result, v)
+		}
+	}
+	if aggregation == avgAggregation {
+		result /= float64(len(values))
+	}
+	return result, nil
+}
+
+// getValuesFromJSON returns the number or the numbers of the array selected by the gjson path valueLocation,
+// eg. `items.#.pending` selects the pending field of all items
+func getValuesFromJSON(body []byte, valueLocation string) ([]float64, error) {
+	r := gjson.GetBytes(body, valueLocation)
+	if !r.IsArray() {
+		if r.Type != gjson.Number {
+			return nil, fmt.Errorf("valueLocation must point to value of type number got: %s", r.Type.String())
+		}
+		return []float64{r.Num}, nil
+	}
+
+	values := []float64{}
+	for _, element := range r.Array() {
+		if element.Type != gjson.Number {
+			return nil, fmt.Errorf("valueLocation must point to an array of numbers, got element of type: %s", element.Type.String())
+		}
+		values = append(values, element.Num)
+	}
+	return values, nil
+}
+
+// getValuesFromXML returns the numbers of all elements matching the path valueLocation, which consists of element names
+// separated by slashes starting at the root element, eg. `/status/queue/pending`. The last part may select an attribute
+// of the elements instead of their text, eg. `/status/queue/@pending`.
+func getValuesFromXML(body []byte, valueLocation string) ([]float64, error) {
+	path := strings.Split(strings.Trim(valueLocation, "/"), "/")
+	attribute := ""
+	if last := path[len(path)-1]; strings.HasPrefix(last, "@") {
+		attribute = strings.TrimPrefix(last, "@")
+		path = path[:len(path)-1]
+	}
+
+	values := []float64{}
+	addValue := func(s string) error {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return fmt.Errorf("valueLocation must point to value of type number got: %s", s)
+		}
+		values = append(values, v)
+		return nil
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	var stack []string
+	var text *strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing xml: %s", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if !matchesXMLPath(stack, path) {
+				continue
+			}
+			if attribute == "" {
+				text = &strings.Builder{}
+				continue
+			}
+			for _, attr := range t.Attr {
+				if attr.Name.Local == attribute {
+					if err := addValue(attr.Value); err != nil {
+						return nil, err
+					}
+				}
+			}
+		case xml.CharData:
+			if text != nil && len(stack) == len(path) {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if text != nil && len(stack) == len(path) {
+				if err := addValue(text.String()); err != nil {
+					return nil, err
+				}
+				text = nil
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	return values, nil
+}
+
+func matchesXMLPath(stack []string, path []string) bool {
+	if len(stack) != len(path) {
+		return false
+	}
+	for i := range path {
+		if stack[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// getValuesFromPrometheus returns the values of all samples matching the selector valueLocation in the Prometheus
+// text exposition format, the selector is a metric name with optional label matchers, eg. `queue_pending{queue="orders"}`.
+// The sum and count of summaries and histograms are selected by the suffixes _sum and _count.
+func getValuesFromPrometheus(body []byte, valueLocation string) ([]float64, error) {
+	name, matchers, err := parsePromSelector(valueLocation)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := expfmt.TextParser{}
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing prometheus metrics: %s", err)
+	}
+
+	values := []float64{}
+	for familyName, family := range families {
+		suffix := ""
+		switch {
+		case familyName == name:
+		case name == familyName+"_sum", name == familyName+"_count":
+			suffix = strings.TrimPrefix(name, familyName)
+		default:
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			if !matchesPromLabels(metric, matchers) {
+				continue
+			}
+			v, err := getPromSampleValue(family.GetType(), metric, suffix)
+			if err != nil {
+				return nil, fmt.Errorf("error reading %s: %s", name, err)
+			}
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+func parsePromSelector(selector string) (string, map[string]string, error) {
+	match := promSelectorRegex.FindStringSubmatch(selector)
+	if match == nil || !promLabelMatchersRegex.MatchString(match[2]) {
+		return "", nil, fmt.Errorf("valueLocation must be a metric name with optional label matchers, eg. metric{label=\"value\"}, but is %s", selector)
+	}
+
+	matchers := map[string]string{}
+	for _, m := range promLabelMatcherRegex.FindAllStringSubmatch(match[2], -1) {
+		value, err := strconv.Unquote(`"` + m[2] + `"`)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid value of label %s: %s", m[1], err)
+		}
+		matchers[m[1]] = value
+	}
+	return match[1], matchers, nil
+}
+
+func matchesPromLabels(metric *dto.Metric, matchers map[string]string) bool {
+	labels := map[string]string{}
+	for _, label := range metric.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	for name, value := range matchers {
+		if labels[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func getPromSampleValue(metricType dto.MetricType, metric *dto.Metric, suffix string) (float64, error) {
+	switch metricType {
+	case dto.MetricType_GAUGE:
+		return metric.GetGauge().GetValue(), nil
+	case dto.MetricType_COUNTER:
+		return metric.GetCounter().GetValue(), nil
+	case dto.MetricType_UNTYPED:
+		return metric.GetUntyped().GetValue(), nil
+	case dto.MetricType_SUMMARY:
+		if suffix == "_sum" {
+			return metric.GetSummary().GetSampleSum(), nil
+		} else if suffix == "_count" {
+			return float64(metric.GetSummary().GetSampleCount()), nil
+		}
+	case dto.MetricType_HISTOGRAM:
+		if suffix == "_sum" {
+			return metric.GetHistogram().GetSampleSum(), nil
+		} else if suffix == "_count" {
+			return float64(metric.GetHistogram().GetSampleCount()), nil
+		}
+	}
+	return 0, errors.New("only the _sum and _count of summaries and histograms can be selected")
+}
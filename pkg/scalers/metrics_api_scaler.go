@@ -12,7 +12,6 @@ import (
 
 	neturl "net/url"
 
-	"github.com/tidwall/gjson"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,9 +28,12 @@ type metricsAPIScaler struct {
 }
 
 type metricsAPIScalerMetadata struct {
-	targetValue   int
-	url           string
-	valueLocation string
+	targetValue           float64
+	activationTargetValue float64
+	url                   string
+	valueLocation         string
+	format                metricsAPIFormat
+	aggregation           metricsAPIAggregation
 
 	//apiKeyAuth
 	enableAPIKeyAuth bool
@@ -61,6 +63,7 @@ const (
 	apiKeyAuth authenticationType = "apiKey"
 	basicAuth  authenticationType = "basic"
 	tlsAuth    authenticationType = "tls"
+	bearerAuth authenticationType = "bearer"
 )
 
 var httpLog = logf.Log.WithName("metrics_api_scaler")
@@ -95,7 +98,7 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 	meta := metricsAPIScalerMetadata{}
 
 	if val, ok := config.TriggerMetadata["targetValue"]; ok {
-		targetValue, err := strconv.Atoi(val)
+		targetValue, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("targetValue parsing error %s", err.Error())
 		}
@@ -110,12 +113,36 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 		return nil, fmt.Errorf("no url given in metadata")
 	}
 
+	meta.format = jsonFormat
+	if val, ok := config.TriggerMetadata["format"]; ok {
+		format, err := parseMetricsAPIFormat(val)
+		if err != nil {
+			return nil, err
+		}
+		meta.format = format
+	}
+
+	// the whole response of the plain text format is the value
 	if val, ok := config.TriggerMetadata["valueLocation"]; ok {
 		meta.valueLocation = val
-	} else {
+	} else if meta.format != plainTextFormat {
 		return nil, fmt.Errorf("no valueLocation given in metadata")
 	}
 
+	if val, ok := config.TriggerMetadata["aggregation"]; ok {
+		aggregation, err := parseMetricsAPIAggregation(val)
+		if err != nil {
+			return nil, err
+		}
+		meta.aggregation = aggregation
+	}
+
+	activation, err := config.GetActivationThreshold("activationTargetValue", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationTargetValue = activation
+
 	authMode, ok := config.TriggerMetadata["authMode"]
 	// no authMode specified
 	if !ok {
@@ -175,17 +202,7 @@ func parseMetricsAPIMetadata(config *ScalerConfig) (*metricsAPIScalerMetadata, e
 	return &meta, nil
 }
 
-// GetValueFromResponse uses provided valueLocation to access the numeric value in provided body
-func GetValueFromResponse(body []byte, valueLocation string) (int64, error) {
-	r := gjson.GetBytes(body, valueLocation)
-	if r.Type != gjson.Number {
-		msg := fmt.Sprintf("valueLocation must point to value of type number got: %s", r.Type.String())
-		return 0, errors.New(msg)
-	}
-	return int64(r.Num), nil
-}
-
-func (s *metricsAPIScaler) getMetricValue() (int64, error) {
+func (s *metricsAPIScaler) getMetricValue() (float64, error) {
 	request, err := getMetricAPIServerRequest(s.metadata)
 	if err != nil {
 		return 0, err
@@ -206,7 +223,7 @@ func (s *metricsAPIScaler) getMetricValue() (int64, error) {
 	if err != nil {
 		return 0, err
 	}
-	v, err := GetValueFromResponse(b, s.metadata.valueLocation)
+	v, err := GetValueFromResponse(b, s.metadata.valueLocation, s.metadata.format, s.metadata.aggregation)
 	if err != nil {
 		return 0, err
 	}
@@ -226,12 +243,12 @@ func (s *metricsAPIScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return v > 0.0, nil
+	return v > s.metadata.activationTargetValue, nil
 }
 
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *metricsAPIScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
-	targetValue := resource.NewQuantity(int64(s.metadata.targetValue), resource.DecimalSI)
+	targetValue := resource.NewMilliQuantity(int64(s.metadata.targetValue*1000), resource.DecimalSI)
 	metricName := kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s", "http", s.metadata.url, s.metadata.valueLocation))
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
@@ -257,7 +274,7 @@ func (s *metricsAPIScaler) GetMetrics(ctx context.Context, metricName string, me
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(v, resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(v*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
@@ -14,8 +14,20 @@ var testMetricsAPIMetadata = []metricsAPIMetadataTestData{
 	{metadata: map[string]string{}, raisesError: true},
 	// OK
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42"}, raisesError: false},
-	// Target not an int
+	// Target not a number
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "aa"}, raisesError: true},
+	// Target is a float
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "0.5"}, raisesError: false},
+	// Prometheus format with aggregation
+	{metadata: map[string]string{"url": "http://dummy:1230/metrics", "valueLocation": "queue_pending", "targetValue": "42", "format": "prometheus", "aggregation": "max"}, raisesError: false},
+	// Plain text format without valueLocation
+	{metadata: map[string]string{"url": "http://dummy:1230/pending", "targetValue": "42", "format": "plain"}, raisesError: false},
+	// Missing valueLocation for XML
+	{metadata: map[string]string{"url": "http://dummy:1230/status.xml", "targetValue": "42", "format": "xml"}, raisesError: true},
+	// Unknown format
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "format": "csv"}, raisesError: true},
+	// Unknown aggregation
+	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "aggregation": "median"}, raisesError: true},
 	// Missing metric name
 	{metadata: map[string]string{"url": "http://dummy:1230/api/v1/", "targetValue": "aa"}, raisesError: true},
 	// Missing url
@@ -67,22 +79,115 @@ func TestParseMetricsAPIMetadata(t *testing.T) {
 	}
 }
 
+func TestParseMetricsAPIMetadataActivationWithoutAuth(t *testing.T) {
+	// the activation threshold must not be skipped when no authMode is given
+	meta, err := parseMetricsAPIMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"url": "http://dummy:1230/api/v1/", "valueLocation": "metric", "targetValue": "42", "activationTargetValue": "3"}})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if meta.activationTargetValue != 3 {
+		t.Errorf("Expected activationTargetValue 3 but got %v", meta.activationTargetValue)
+	}
+}
+
 func TestGetValueFromResponse(t *testing.T) {
 	d := []byte(`{"components":[{"id": "82328e93e", "tasks": 32}],"count":2.43}`)
-	v, err := GetValueFromResponse(d, "components.0.tasks")
+	v, err := GetValueFromResponse(d, "components.0.tasks", jsonFormat, noAggregation)
 	if err != nil {
 		t.Error("Expected success but got error", err)
 	}
 	if v != 32 {
-		t.Errorf("Expected %d got %d", 32, v)
+		t.Errorf("Expected %d got %f", 32, v)
 	}
 
-	v, err = GetValueFromResponse(d, "count")
+	v, err = GetValueFromResponse(d, "count", jsonFormat, noAggregation)
 	if err != nil {
 		t.Error("Expected success but got error", err)
 	}
-	if v != 2 {
-		t.Errorf("Expected %d got %d", 2, v)
+	if v != 2.43 {
+		t.Errorf("Expected %f got %f", 2.43, v)
+	}
+}
+
+type metricsAPIFormatTestData struct {
+	body          string
+	valueLocation string
+	format        metricsAPIFormat
+	aggregation   metricsAPIAggregation
+	value         float64
+	isError       bool
+}
+
+const testMetricsAPIPrometheusBody = `# HELP queue_pending Pending messages
+# TYPE queue_pending gauge
+queue_pending{queue="orders",shard="1"} 3
+queue_pending{queue="orders",shard="2"} 5.5
+queue_pending{queue="payments",shard="1"} 1
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="1"} 2
+request_duration_seconds_bucket{le="+Inf"} 4
+request_duration_seconds_sum 7.5
+request_duration_seconds_count 4
+`
+
+const testMetricsAPIXMLBody = `<?xml version="1.0"?>
+<status>
+  <queue name="orders" pending="3"><depth>4</depth></queue>
+  <queue name="payments" pending="1.5"><depth>2</depth></queue>
+  <workers>2</workers>
+</status>`
+
+var testMetricsAPIFormats = []metricsAPIFormatTestData{
+	// JSON array aggregation
+	{`{"items":[{"pending":2},{"pending":5}]}`, "items.#.pending", jsonFormat, sumAggregation, 7, false},
+	{`{"items":[{"pending":2},{"pending":5}]}`, "items.#.pending", jsonFormat, maxAggregation, 5, false},
+	{`{"items":[{"pending":2},{"pending":5}]}`, "items.#.pending", jsonFormat, noAggregation, 0, true},
+	{`{"items":[{"pending":2}]}`, "items.#.pending", jsonFormat, noAggregation, 2, false},
+	{`{"items":[{"pending":"2"}]}`, "items.#.pending", jsonFormat, sumAggregation, 0, true},
+	{`{"items":[]}`, "items.#.pending", jsonFormat, sumAggregation, 0, false},
+	{`{"items":[]}`, "items.#.pending", jsonFormat, avgAggregation, 0, false},
+	{`{"items":[]}`, "items.#.pending", jsonFormat, noAggregation, 0, true},
+	// YAML
+	{"queue:\n  pending: 12.5\n", "queue.pending", yamlFormat, noAggregation, 12.5, false},
+	{"items:\n- pending: 1\n- pending: 3\n", "items.#.pending", yamlFormat, avgAggregation, 2, false},
+	// XML
+	{testMetricsAPIXMLBody, "/status/workers", xmlFormat, noAggregation, 2, false},
+	{testMetricsAPIXMLBody, "status/queue/depth", xmlFormat, sumAggregation, 6, false},
+	{testMetricsAPIXMLBody, "/status/queue/@pending", xmlFormat, minAggregation, 1.5, false},
+	{testMetricsAPIXMLBody, "/status/queue/@name", xmlFormat, sumAggregation, 0, true},
+	{testMetricsAPIXMLBody, "/status/missing", xmlFormat, noAggregation, 0, true},
+	{"<status>", "/status", xmlFormat, noAggregation, 0, true},
+	// Prometheus
+	{testMetricsAPIPrometheusBody, `queue_pending{queue="payments"}`, prometheusFormat, noAggregation, 1, false},
+	{testMetricsAPIPrometheusBody, `queue_pending{queue="orders"}`, prometheusFormat, sumAggregation, 8.5, false},
+	{testMetricsAPIPrometheusBody, `queue_pending`, prometheusFormat, maxAggregation, 5.5, false},
+	{testMetricsAPIPrometheusBody, `queue_pending{queue="orders", shard="2"}`, prometheusFormat, noAggregation, 5.5, false},
+	{testMetricsAPIPrometheusBody, `request_duration_seconds_count`, prometheusFormat, noAggregation, 4, false},
+	{testMetricsAPIPrometheusBody, `request_duration_seconds`, prometheusFormat, noAggregation, 0, true},
+	{testMetricsAPIPrometheusBody, `queue_pending{queue=orders}`, prometheusFormat, noAggregation, 0, true},
+	{testMetricsAPIPrometheusBody, `queue_pending{queue="unknown"}`, prometheusFormat, noAggregation, 0, true},
+	{testMetricsAPIPrometheusBody, `queue_pending{queue="unknown"}`, prometheusFormat, maxAggregation, 0, false},
+	// Plain text
+	{" 42.5\n", "", plainTextFormat, noAggregation, 42.5, false},
+	{"pending: 42", "", plainTextFormat, noAggregation, 0, true},
+}
+
+func TestGetValueFromResponseFormats(t *testing.T) {
+	for _, testData := range testMetricsAPIFormats {
+		v, err := GetValueFromResponse([]byte(testData.body), testData.valueLocation, testData.format, testData.aggregation)
+		if testData.isError {
+			if err == nil {
+				t.Errorf("Expected error but got success for %s %s", testData.format, testData.valueLocation)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected success but got error for %s %s: %s", testData.format, testData.valueLocation, err)
+			continue
+		}
+		if v != testData.value {
+			t.Errorf("Expected %f got %f for %s %s", testData.value, v, testData.format, testData.valueLocation)
+		}
 	}
 }
 
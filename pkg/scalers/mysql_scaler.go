@@ -23,14 +23,15 @@ type mySQLScaler struct {
 }
 
 type mySQLMetadata struct {
-	connectionString string // Database connection string
-	username         string
-	password         string
-	host             string
-	port             string
-	dbName           string
-	query            string
-	queryValue       int
+	connectionString     string // Database connection string
+	username             string
+	password             string
+	host                 string
+	port                 string
+	dbName               string
+	query                string
+	queryValue           int
+	activationQueryValue float64
 }
 
 var mySQLLog = logf.Log.WithName("mysql_scaler")
@@ -110,6 +111,12 @@ func parseMySQLMetadata(config *ScalerConfig) (*mySQLMetadata, error) {
 		}
 	}
 
+	activation, err := config.GetActivationThreshold("activationQueryValue", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationQueryValue = activation
+
 	return &meta, nil
 }
 
@@ -165,7 +172,7 @@ func (s *mySQLScaler) IsActive(ctx context.Context) (bool, error) {
 		mySQLLog.Error(err, fmt.Sprintf("Error inspecting MySQL: %s", err))
 		return false, err
 	}
-	return messages > 0, nil
+	return float64(messages) > s.metadata.activationQueryValue, nil
 }
 
 // getQueryResult returns result of the scaler query
@@ -24,15 +24,16 @@ type postgreSQLScaler struct {
 }
 
 type postgreSQLMetadata struct {
-	targetQueryValue int
-	connection       string
-	userName         string
-	password         string
-	host             string
-	port             string
-	query            string
-	dbName           string
-	sslmode          string
+	targetQueryValue           int
+	activationTargetQueryValue float64
+	connection                 string
+	userName                   string
+	password                   string
+	host                       string
+	port                       string
+	query                      string
+	dbName                     string
+	sslmode                    string
 }
 
 var postgreSQLLog = logf.Log.WithName("postgreSQL_scaler")
@@ -113,6 +114,12 @@ func parsePostgreSQLMetadata(config *ScalerConfig) (*postgreSQLMetadata, error)
 		}
 	}
 
+	activation, err := config.GetActivationThreshold("activationTargetQueryValue", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationTargetQueryValue = activation
+
 	return &meta, nil
 }
 
@@ -161,7 +168,7 @@ func (s *postgreSQLScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, fmt.Errorf("error inspecting postgreSQL: %s", err)
 	}
 
-	return messages > 0, nil
+	return float64(messages) > s.metadata.activationTargetQueryValue, nil
 }
 
 func (s *postgreSQLScaler) getActiveNumber() (int, error) {
@@ -3,11 +3,14 @@ package scalers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
 	url_pkg "net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
@@ -25,31 +28,75 @@ const (
 	promMetricName    = "metricName"
 	promQuery         = "query"
 	promThreshold     = "threshold"
+	promNamespace     = "namespace"
+	promCustomHeaders = "customHeaders"
+	promAuthModes     = "authModes"
+	promAggregation   = "seriesAggregation"
+
+	// promTenantHeader selects the tenant in multi-tenant Prometheus frontends (Cortex, Thanos, Mimir)
+	promTenantHeader = "X-Scope-OrgID"
 )
 
 type prometheusScaler struct {
-	metadata *prometheusMetadata
+	metadata   *prometheusMetadata
+	httpClient *http.Client
 }
 
 type prometheusMetadata struct {
-	serverAddress string
-	metricName    string
-	query         string
-	threshold     int
+	serverAddress       string
+	metricName          string
+	query               string
+	threshold           float64
+	activationThreshold float64
+	aggregation         promSeriesAggregation
+	namespace           string
+	customHeaders       map[string]string
+
+	// bearer auth
+	enableBearerAuth bool
+	bearerToken      string
+
+	// basic auth
+	enableBasicAuth bool
+	username        string
+	password        string // +optional
+
+	// client certification
+	enableTLS bool
+	cert      string
+	key       string
+	ca        string // +optional
 }
 
+// promSeriesAggregation reduces the values of a query returning multiple series to a single value
+type promSeriesAggregation string
+
+const (
+	promAggregationError promSeriesAggregation = "error"
+	promAggregationSum   promSeriesAggregation = "sum"
+	promAggregationMax   promSeriesAggregation = "max"
+	promAggregationMin   promSeriesAggregation = "min"
+	promAggregationAvg   promSeriesAggregation = "avg"
+)
+
 type promQueryResult struct {
 	Status string `json:"status"`
+	Error  string `json:"error"`
 	Data   struct {
 		ResultType string `json:"resultType"`
-		Result     []struct {
-			Metric struct {
-			} `json:"metric"`
-			Value []interface{} `json:"value"`
-		} `json:"result"`
+		// vector and matrix results are lists of series, scalar results are a single sample
+		Result json.RawMessage `json:"result"`
 	} `json:"data"`
 }
 
+type promSeries struct {
+	Metric map[string]string `json:"metric"`
+	// sample of a vector result
+	Value []interface{} `json:"value"`
+	// samples of a matrix result
+	Values [][]interface{} `json:"values"`
+}
+
 var prometheusLog = logf.Log.WithName("prometheus_scaler")
 
 // NewPrometheusScaler creates a new prometheusScaler
@@ -59,8 +106,22 @@ func NewPrometheusScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing prometheus metadata: %s", err)
 	}
 
+	httpClient := &http.Client{
+		Timeout: defaultTimeOut,
+	}
+
+	if meta.enableTLS {
+		config, err := kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca)
+		if err != nil {
+			return nil, err
+		}
+
+		httpClient.Transport = &http.Transport{TLSClientConfig: config}
+	}
+
 	return &prometheusScaler{
-		metadata: meta,
+		metadata:   meta,
+		httpClient: httpClient,
 	}, nil
 }
 
@@ -86,7 +147,7 @@ func parsePrometheusMetadata(config *ScalerConfig) (*prometheusMetadata, error)
 	}
 
 	if val, ok := config.TriggerMetadata[promThreshold]; ok && val != "" {
-		t, err := strconv.Atoi(val)
+		t, err := strconv.ParseFloat(val, 64)
 		if err != nil {
 			return nil, fmt.Errorf("error parsing %s: %s", promThreshold, err)
 		}
@@ -94,9 +155,94 @@ func parsePrometheusMetadata(config *ScalerConfig) (*prometheusMetadata, error)
 		meta.threshold = t
 	}
 
+	activation, err := config.GetActivationThreshold("activationThreshold", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationThreshold = activation
+
+	meta.aggregation = promAggregationError
+	if val, ok := config.TriggerMetadata[promAggregation]; ok && val != "" {
+		aggregation := promSeriesAggregation(strings.TrimSpace(val))
+		switch aggregation {
+		case promAggregationError, promAggregationSum, promAggregationMax, promAggregationMin, promAggregationAvg:
+			meta.aggregation = aggregation
+		default:
+			return nil, fmt.Errorf("err incorrect value for %s is given: %s", promAggregation, val)
+		}
+	}
+
+	meta.namespace = config.TriggerMetadata[promNamespace]
+
+	meta.customHeaders = map[string]string{}
+	if val, ok := config.TriggerMetadata[promCustomHeaders]; ok && val != "" {
+		for _, header := range strings.Split(val, ",") {
+			keyValue := strings.SplitN(header, "=", 2)
+			if len(keyValue) != 2 || strings.TrimSpace(keyValue[0]) == "" {
+				return nil, fmt.Errorf("error parsing %s: %s is not in the form key=value", promCustomHeaders, header)
+			}
+			meta.customHeaders[strings.TrimSpace(keyValue[0])] = strings.TrimSpace(keyValue[1])
+		}
+	}
+
+	if err := parsePrometheusAuthModes(config, &meta); err != nil {
+		return nil, err
+	}
+
 	return &meta, nil
 }
 
+// parsePrometheusAuthModes parses the comma-separated authModes, the credentials are taken from the TriggerAuthentication,
+// tls can be combined with bearer or basic
+func parsePrometheusAuthModes(config *ScalerConfig, meta *prometheusMetadata) error {
+	authModes, ok := config.TriggerMetadata[promAuthModes]
+	// no authMode specified
+	if !ok || authModes == "" {
+		return nil
+	}
+
+	for _, authMode := range strings.Split(authModes, ",") {
+		authType := authenticationType(strings.TrimSpace(authMode))
+		switch authType {
+		case bearerAuth:
+			if len(config.AuthParams["bearerToken"]) == 0 {
+				return errors.New("no bearerToken given")
+			}
+			meta.bearerToken = config.AuthParams["bearerToken"]
+			meta.enableBearerAuth = true
+		case basicAuth:
+			if len(config.AuthParams["username"]) == 0 {
+				return errors.New("no username given")
+			}
+			meta.username = config.AuthParams["username"]
+			// password is optional
+			meta.password = config.AuthParams["password"]
+			meta.enableBasicAuth = true
+		case tlsAuth:
+			if len(config.AuthParams["cert"]) == 0 {
+				return errors.New("no cert given")
+			}
+			meta.cert = config.AuthParams["cert"]
+
+			if len(config.AuthParams["key"]) == 0 {
+				return errors.New("no key given")
+			}
+			meta.key = config.AuthParams["key"]
+			// ca is optional, the system CAs are used if not given
+			meta.ca = config.AuthParams["ca"]
+			meta.enableTLS = true
+		default:
+			return fmt.Errorf("err incorrect value for authModes is given: %s", authMode)
+		}
+	}
+
+	if meta.enableBearerAuth && meta.enableBasicAuth {
+		return errors.New("bearer and basic authModes can't be used together")
+	}
+
+	return nil
+}
+
 func (s *prometheusScaler) IsActive(ctx context.Context) (bool, error) {
 	val, err := s.ExecutePromQuery()
 	if err != nil {
@@ -104,7 +250,7 @@ func (s *prometheusScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return val > 0, nil
+	return val > s.metadata.activationThreshold, nil
 }
 
 func (s *prometheusScaler) Close() error {
@@ -112,7 +258,7 @@ func (s *prometheusScaler) Close() error {
 }
 
 func (s *prometheusScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
-	targetMetricValue := resource.NewQuantity(int64(s.metadata.threshold), resource.DecimalSI)
+	targetMetricValue := resource.NewMilliQuantity(int64(s.metadata.threshold*1000), resource.DecimalSI)
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
 			Name: kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s", "prometheus", s.metadata.serverAddress, s.metadata.metricName)),
@@ -132,7 +278,24 @@ func (s *prometheusScaler) ExecutePromQuery() (float64, error) {
 	t := time.Now().UTC().Format(time.RFC3339)
 	queryEscaped := url_pkg.QueryEscape(s.metadata.query)
 	url := fmt.Sprintf("%s/api/v1/query?query=%s&time=%s", s.metadata.serverAddress, queryEscaped, t)
-	r, err := http.Get(url)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return -1, err
+	}
+
+	for key, value := range s.metadata.customHeaders {
+		req.Header.Set(key, value)
+	}
+	if s.metadata.namespace != "" {
+		req.Header.Set(promTenantHeader, s.metadata.namespace)
+	}
+	if s.metadata.enableBearerAuth {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.metadata.bearerToken))
+	} else if s.metadata.enableBasicAuth {
+		req.SetBasicAuth(s.metadata.username, s.metadata.password)
+	}
+
+	r, err := s.httpClient.Do(req)
 	if err != nil {
 		return -1, err
 	}
@@ -143,34 +306,124 @@ func (s *prometheusScaler) ExecutePromQuery() (float64, error) {
 	}
 	r.Body.Close()
 
+	if r.StatusCode != http.StatusOK {
+		return -1, fmt.Errorf("prometheus query api returned %d: %s", r.StatusCode, string(b))
+	}
+
 	var result promQueryResult
 	err = json.Unmarshal(b, &result)
 	if err != nil {
 		return -1, err
 	}
+	if result.Status != "success" {
+		return -1, fmt.Errorf("prometheus query %s failed: %s", s.metadata.query, result.Error)
+	}
 
-	var v float64 = -1
+	values, err := parsePromQueryValues(&result)
+	if err != nil {
+		prometheusLog.Error(err, "Error converting prometheus value", "query", s.metadata.query)
+		return -1, err
+	}
 
-	// allow for zero element or single element result sets
-	if len(result.Data.Result) == 0 {
-		return 0, nil
-	} else if len(result.Data.Result) > 1 {
-		return -1, fmt.Errorf("prometheus query %s returned multiple elements", s.metadata.query)
+	return aggregatePromValues(values, s.metadata.aggregation, s.metadata.query)
+}
+
+// parsePromQueryValues returns the value of each series of a vector result, the latest value of each series
+// of a matrix result or the value of a scalar result
+func parsePromQueryValues(result *promQueryResult) ([]float64, error) {
+	if result.Data.ResultType == "scalar" {
+		var sample []interface{}
+		if err := json.Unmarshal(result.Data.Result, &sample); err != nil {
+			return nil, err
+		}
+		v, err := parsePromSampleValue(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+	}
+
+	var series []promSeries
+	if err := json.Unmarshal(result.Data.Result, &series); err != nil {
+		return nil, err
 	}
 
-	val := result.Data.Result[0].Value[1]
-	if val != nil {
-		s := val.(string)
-		v, err = strconv.ParseFloat(s, 64)
+	values := make([]float64, 0, len(series))
+	for _, s := range series {
+		var sample []interface{}
+		switch result.Data.ResultType {
+		case "vector":
+			sample = s.Value
+		case "matrix":
+			if len(s.Values) == 0 {
+				continue
+			}
+			sample = s.Values[len(s.Values)-1]
+		default:
+			return nil, fmt.Errorf("unsupported prometheus result type %s", result.Data.ResultType)
+		}
+
+		v, err := parsePromSampleValue(sample)
 		if err != nil {
-			prometheusLog.Error(err, "Error converting prometheus value", "prometheus_value", s)
-			return -1, err
+			return nil, err
 		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// parsePromSampleValue parses the value of a [<timestamp>, "<value>"] sample
+func parsePromSampleValue(sample []interface{}) (float64, error) {
+	if len(sample) != 2 {
+		return -1, fmt.Errorf("unexpected prometheus sample %v", sample)
+	}
+	s, ok := sample[1].(string)
+	if !ok {
+		return -1, fmt.Errorf("unexpected prometheus sample value %v", sample[1])
 	}
 
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return -1, err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return -1, fmt.Errorf("prometheus sample value %s can't be used as metric", s)
+	}
 	return v, nil
 }
 
+// aggregatePromValues reduces the values of multiple series to a single value, an empty result is reported as 0
+func aggregatePromValues(values []float64, aggregation promSeriesAggregation, query string) (float64, error) {
+	switch len(values) {
+	case 0:
+		return 0, nil
+	case 1:
+		return values[0], nil
+	}
+
+	result := values[0]
+	switch aggregation {
+	case promAggregationSum, promAggregationAvg:
+		for _, v := range values[1:] {
+			result += v
+		}
+		if aggregation == promAggregationAvg {
+			result /= float64(len(values))
+		}
+	case promAggregationMax:
+		for _, v := range values[1:] {
+			result = math.Max(result, v)
+		}
+	case promAggregationMin:
+		for _, v := range values[1:] {
+			result = math.Min(result, v)
+		}
+	default:
+		return -1, fmt.Errorf("prometheus query %s returned multiple elements", query)
+	}
+	return result, nil
+}
+
 func (s *prometheusScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
 	val, err := s.ExecutePromQuery()
 	if err != nil {
@@ -180,7 +433,7 @@ func (s *prometheusScaler) GetMetrics(ctx context.Context, metricName string, me
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(val), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(val*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
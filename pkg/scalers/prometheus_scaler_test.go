@@ -1,6 +1,11 @@
 package scalers
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -28,6 +33,12 @@ var testPromMetadata = []parsePrometheusMetadataTestData{
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "", "disableScaleToZero": "true"}, true},
 	// all properly formed, default disableScaleToZero
 	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up"}, false},
+	// float threshold
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "0.75", "query": "up"}, false},
+	// seriesAggregation
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "seriesAggregation": "max"}, false},
+	// invalid seriesAggregation
+	{map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up", "seriesAggregation": "median"}, true},
 }
 
 var prometheusMetricIdentifiers = []prometheusMetricIdentifier{
@@ -52,7 +63,7 @@ func TestPrometheusGetMetricSpecForScaling(t *testing.T) {
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
-		mockPrometheusScaler := prometheusScaler{meta}
+		mockPrometheusScaler := prometheusScaler{metadata: meta}
 
 		metricSpec := mockPrometheusScaler.GetMetricSpecForScaling()
 		metricName := metricSpec[0].External.Metric.Name
@@ -61,3 +72,194 @@ func TestPrometheusGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+type parsePrometheusAuthParamsTestData struct {
+	metadata         map[string]string
+	authParams       map[string]string
+	isError          bool
+	enableBearerAuth bool
+	enableBasicAuth  bool
+	enableTLS        bool
+}
+
+var testPrometheusAuthParams = []parsePrometheusAuthParamsTestData{
+	// no authModes
+	{map[string]string{}, map[string]string{"bearerToken": "token"}, false, false, false, false},
+	// bearer
+	{map[string]string{"authModes": "bearer"}, map[string]string{"bearerToken": "token"}, false, true, false, false},
+	// bearer without token
+	{map[string]string{"authModes": "bearer"}, map[string]string{}, true, false, false, false},
+	// basic
+	{map[string]string{"authModes": "basic"}, map[string]string{"username": "user", "password": "pass"}, false, false, true, false},
+	// basic without password
+	{map[string]string{"authModes": "basic"}, map[string]string{"username": "user"}, false, false, true, false},
+	// basic without username
+	{map[string]string{"authModes": "basic"}, map[string]string{"password": "pass"}, true, false, false, false},
+	// tls
+	{map[string]string{"authModes": "tls"}, map[string]string{"cert": "cert", "key": "key", "ca": "ca"}, false, false, false, true},
+	// tls without ca
+	{map[string]string{"authModes": "tls"}, map[string]string{"cert": "cert", "key": "key"}, false, false, false, true},
+	// tls without key
+	{map[string]string{"authModes": "tls"}, map[string]string{"cert": "cert"}, true, false, false, false},
+	// tls and bearer
+	{map[string]string{"authModes": "tls, bearer"}, map[string]string{"cert": "cert", "key": "key", "bearerToken": "token"}, false, true, false, true},
+	// bearer and basic
+	{map[string]string{"authModes": "bearer,basic"}, map[string]string{"username": "user", "bearerToken": "token"}, true, false, false, false},
+	// unknown authMode
+	{map[string]string{"authModes": "apiKey"}, map[string]string{}, true, false, false, false},
+	// custom headers
+	{map[string]string{"customHeaders": "X-Client-Id=keda, X-Env=prod"}, map[string]string{}, false, false, false, false},
+	// malformed custom headers
+	{map[string]string{"customHeaders": "X-Client-Id"}, map[string]string{}, true, false, false, false},
+}
+
+func TestPrometheusScalerAuthParams(t *testing.T) {
+	for _, testData := range testPrometheusAuthParams {
+		metadata := map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up"}
+		for k, v := range testData.metadata {
+			metadata[k] = v
+		}
+
+		meta, err := parsePrometheusMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: testData.authParams})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for %v: %s", testData.metadata, err)
+			continue
+		}
+		if testData.isError {
+			if err == nil {
+				t.Errorf("Expected error but got success for %v", testData.metadata)
+			}
+			continue
+		}
+		if meta.enableBearerAuth != testData.enableBearerAuth || meta.enableBasicAuth != testData.enableBasicAuth || meta.enableTLS != testData.enableTLS {
+			t.Errorf("Unexpected auth modes for %v: bearer %v, basic %v, tls %v", testData.metadata, meta.enableBearerAuth, meta.enableBasicAuth, meta.enableTLS)
+		}
+	}
+}
+
+func TestPrometheusScalerRequestHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.Header.Get("X-Scope-OrgID") != "tenant-1" || r.Header.Get("X-Client-Id") != "keda" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1600000000,"12"]}]}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"serverAddress": server.URL, "metricName": "http_requests_total", "threshold": "100", "query": "up", "namespace": "tenant-1", "customHeaders": "X-Client-Id=keda", "authModes": "bearer"}
+	meta, err := parsePrometheusMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"bearerToken": "token"}})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient}
+	value, err := scaler.ExecutePromQuery()
+	if err != nil {
+		t.Fatal("Expected success but got error:", err)
+	}
+	if value != 12 {
+		t.Errorf("Expected value 12 but got %v", value)
+	}
+
+	meta.bearerToken = "wrong"
+	if _, err = scaler.ExecutePromQuery(); err == nil {
+		t.Error("Expected error for unauthorized request but got success")
+	}
+}
+
+func newTestPromQueryResult(t *testing.T, body string) *promQueryResult {
+	result := &promQueryResult{}
+	if err := json.Unmarshal([]byte(body), result); err != nil {
+		t.Fatal("Could not unmarshal result:", err)
+	}
+	return result
+}
+
+func TestParsePromQueryValues(t *testing.T) {
+	testData := []struct {
+		body    string
+		values  []float64
+		isError bool
+	}{
+		{`{"status":"success","data":{"resultType":"vector","result":[]}}`, []float64{}, false},
+		{`{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pod":"a"},"value":[1600000000,"0.5"]},{"metric":{"pod":"b"},"value":[1600000000,"2"]}]}}`, []float64{0.5, 2}, false},
+		{`{"status":"success","data":{"resultType":"matrix","result":[{"metric":{},"values":[[1600000000,"1"],[1600000015,"3"]]},{"metric":{},"values":[]}]}}`, []float64{3}, false},
+		{`{"status":"success","data":{"resultType":"scalar","result":[1600000000,"4.25"]}}`, []float64{4.25}, false},
+		{`{"status":"success","data":{"resultType":"string","result":[1600000000,"foo"]}}`, nil, true},
+		{`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1600000000,"NaN"]}]}}`, nil, true},
+		{`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1600000000,"one"]}]}}`, nil, true},
+	}
+
+	for _, d := range testData {
+		values, err := parsePromQueryValues(newTestPromQueryResult(t, d.body))
+		if d.isError {
+			if err == nil {
+				t.Errorf("Expected error but got success for %s", d.body)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Expected success but got error for %s: %s", d.body, err)
+			continue
+		}
+		if fmt.Sprint(values) != fmt.Sprint(d.values) {
+			t.Errorf("Expected values %v but got %v", d.values, values)
+		}
+	}
+}
+
+func TestAggregatePromValues(t *testing.T) {
+	values := []float64{1, 4, 2.5}
+	expected := map[promSeriesAggregation]float64{
+		promAggregationSum: 7.5,
+		promAggregationMax: 4,
+		promAggregationMin: 1,
+		promAggregationAvg: 2.5,
+	}
+	for aggregation, value := range expected {
+		v, err := aggregatePromValues(values, aggregation, "up")
+		if err != nil || v != value {
+			t.Errorf("Expected %v for %s but got %v (%v)", value, aggregation, v, err)
+		}
+	}
+
+	if _, err := aggregatePromValues(values, promAggregationError, "up"); err == nil {
+		t.Error("Expected error for multiple elements but got success")
+	}
+	if v, err := aggregatePromValues([]float64{0.75}, promAggregationError, "up"); err != nil || v != 0.75 {
+		t.Errorf("Expected 0.75 for a single element but got %v (%v)", v, err)
+	}
+	if v, err := aggregatePromValues(nil, promAggregationError, "up"); err != nil || v != 0 {
+		t.Errorf("Expected 0 for no elements but got %v (%v)", v, err)
+	}
+}
+
+func TestPrometheusScalerGetMetricsMilliValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{"pod":"a"},"value":[1600000000,"0.5"]},{"metric":{"pod":"b"},"value":[1600000000,"1"]}]}}`)
+	}))
+	defer server.Close()
+
+	metadata := map[string]string{"serverAddress": server.URL, "metricName": "ratio", "threshold": "0.5", "query": "ratio", "seriesAggregation": "avg"}
+	meta, err := parsePrometheusMetadata(&ScalerConfig{TriggerMetadata: metadata})
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	scaler := prometheusScaler{metadata: meta, httpClient: http.DefaultClient}
+
+	metrics, err := scaler.GetMetrics(context.TODO(), "ratio", nil)
+	if err != nil {
+		t.Fatal("Expected success but got error:", err)
+	}
+	if metrics[0].Value.MilliValue() != 750 {
+		t.Errorf("Expected metric value 750m but got %s", metrics[0].Value.String())
+	}
+	if target := scaler.GetMetricSpecForScaling()[0].External.Target.AverageValue.MilliValue(); target != 500 {
+		t.Errorf("Expected target 500m but got %d", target)
+	}
+}
@@ -2,12 +2,16 @@ package scalers
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/streadway/amqp"
@@ -33,23 +37,71 @@ const (
 	defaultProtocol = amqpProtocol
 )
 
+type rabbitMQMode string
+
+const (
+	rabbitModeQueueLength rabbitMQMode = "QueueLength"
+	rabbitModeMessageRate rabbitMQMode = "MessageRate"
+)
+
+type rabbitMQOperation string
+
+const (
+	rabbitOperationSum rabbitMQOperation = "sum"
+	rabbitOperationMax rabbitMQOperation = "max"
+)
+
+const (
+	defaultRabbitMQPageSize = 100
+)
+
+var rabbitMetricNameInvalidChars = regexp.MustCompile(`-?[^a-zA-Z0-9-]+-?`)
+
 type rabbitMQScaler struct {
 	metadata   *rabbitMQMetadata
 	connection *amqp.Connection
 	channel    *amqp.Channel
+	httpClient *http.Client
 }
 
 type rabbitMQMetadata struct {
-	queueName   string
-	queueLength int
-	host        string // connection string for either HTTP or AMQP protocol
-	protocol    string // either http or amqp protocol
+	queueName       string
+	mode            rabbitMQMode      // either QueueLength or MessageRate
+	value           float64           // target queue length or publish rate per replica
+	activationValue float64           // queue length or publish rate above which the scaler is active
+	host            string            // connection string for either HTTP or AMQP protocol
+	protocol        string            // either http or amqp protocol
+	vhostName       string            // overrides the vhost given in host
+	useRegex        bool              // queueName is a regex matching multiple queues
+	operation       rabbitMQOperation // aggregation of the values of the queues matching queueName
+	pageSize        int               // page size when listing the queues matching queueName
+
+	// TLS
+	enableTLS bool
+	ca        string
+	cert      string
+	key       string
 }
 
 type queueInfo struct {
-	Messages               int    `json:"messages"`
-	MessagesUnacknowledged int    `json:"messages_unacknowledged"`
-	Name                   string `json:"name"`
+	Messages               int         `json:"messages"`
+	MessagesUnacknowledged int         `json:"messages_unacknowledged"`
+	MessageStat            messageStat `json:"message_stats"`
+	Name                   string      `json:"name"`
+}
+
+type messageStat struct {
+	PublishDetail publishDetail `json:"publish_details"`
+}
+
+type publishDetail struct {
+	Rate float64 `json:"rate"`
+}
+
+type queuesPage struct {
+	Items     []queueInfo `json:"items"`
+	Page      int         `json:"page"`
+	PageCount int         `json:"page_count"`
 }
 
 var rabbitmqLog = logf.Log.WithName("rabbitmq_scaler")
@@ -61,11 +113,28 @@ func NewRabbitMQScaler(config *ScalerConfig) (Scaler, error) {
 		return nil, fmt.Errorf("error parsing rabbitmq metadata: %s", err)
 	}
 
+	var tlsConfig *tls.Config
+	if meta.enableTLS {
+		tlsConfig, err = kedautil.NewTLSConfig(meta.cert, meta.key, meta.ca)
+		if err != nil {
+			return nil, fmt.Errorf("error creating rabbitmq tls config: %s", err)
+		}
+	}
+
 	if meta.protocol == httpProtocol {
-		return &rabbitMQScaler{metadata: meta}, nil
+		httpClient := &http.Client{Timeout: 5 * time.Second}
+		if tlsConfig != nil {
+			httpClient.Transport = &http.Transport{TLSClientConfig: tlsConfig}
+		}
+		return &rabbitMQScaler{metadata: meta, httpClient: httpClient}, nil
+	}
+
+	host, err := getRabbitMQAMQPHost(meta)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing rabbitmq host: %s", err)
 	}
 
-	conn, ch, err := getConnectionAndChannel(meta.host)
+	conn, ch, err := getConnectionAndChannel(host, tlsConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing rabbitmq connection: %s", err)
 	}
@@ -108,23 +177,190 @@ func parseRabbitMQMetadata(config *ScalerConfig) (*rabbitMQMetadata, error) {
 		return nil, fmt.Errorf("no queue name given")
 	}
 
-	// Resolve queueLength
-	if val, ok := config.TriggerMetadata[rabbitQueueLengthMetricName]; ok {
-		queueLength, err := strconv.Atoi(val)
-		if err != nil {
-			return nil, fmt.Errorf("can't parse %s: %s", rabbitQueueLengthMetricName, err)
+	// Resolve vhostName
+	meta.vhostName = config.TriggerMetadata["vhostName"]
+
+	if err := parseRabbitMQModeAndValue(config, &meta); err != nil {
+		return nil, err
+	}
+
+	if err := parseRabbitMQQueueSelection(config, &meta); err != nil {
+		return nil, err
+	}
+
+	// Resolve TLS
+	if val, ok := config.AuthParams["tls"]; ok {
+		val = strings.TrimSpace(val)
+		if val != "enable" {
+			return nil, fmt.Errorf("err incorrect value for TLS given: %s", val)
 		}
 
-		meta.queueLength = queueLength
-	} else {
-		meta.queueLength = defaultRabbitMQQueueLength
+		certGiven := config.AuthParams["cert"] != ""
+		keyGiven := config.AuthParams["key"] != ""
+		if certGiven && !keyGiven {
+			return nil, fmt.Errorf("key must be provided with cert")
+		}
+		if keyGiven && !certGiven {
+			return nil, fmt.Errorf("cert must be provided with key")
+		}
+		meta.ca = config.AuthParams["ca"]
+		meta.cert = config.AuthParams["cert"]
+		meta.key = config.AuthParams["key"]
+		meta.enableTLS = true
 	}
 
 	return &meta, nil
 }
 
-func getConnectionAndChannel(host string) (*amqp.Connection, *amqp.Channel, error) {
-	conn, err := amqp.Dial(host)
+// parseRabbitMQModeAndValue resolves the mode and its target value, queueLength is still accepted
+// as the target of the QueueLength mode
+func parseRabbitMQModeAndValue(config *ScalerConfig, meta *rabbitMQMetadata) error {
+	mode, modeGiven := config.TriggerMetadata["mode"]
+	value, valueGiven := config.TriggerMetadata["value"]
+	queueLength, queueLengthGiven := config.TriggerMetadata[rabbitQueueLengthMetricName]
+
+	switch {
+	case modeGiven && queueLengthGiven:
+		return fmt.Errorf("%s is deprecated and must not be used together with mode, use value instead", rabbitQueueLengthMetricName)
+	case modeGiven:
+		switch rabbitMQMode(mode) {
+		case rabbitModeQueueLength, rabbitModeMessageRate:
+			meta.mode = rabbitMQMode(mode)
+		default:
+			return fmt.Errorf("the mode has to be either `%s` or `%s` but is `%s`", rabbitModeQueueLength, rabbitModeMessageRate, mode)
+		}
+		if !valueGiven {
+			return fmt.Errorf("no value given for mode %s", mode)
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("can't parse value: %s", err)
+		}
+		meta.value = v
+	case valueGiven:
+		return fmt.Errorf("value must be used together with mode")
+	case queueLengthGiven:
+		v, err := strconv.Atoi(queueLength)
+		if err != nil {
+			return fmt.Errorf("can't parse %s: %s", rabbitQueueLengthMetricName, err)
+		}
+		meta.mode = rabbitModeQueueLength
+		meta.value = float64(v)
+	default:
+		meta.mode = rabbitModeQueueLength
+		meta.value = defaultRabbitMQQueueLength
+	}
+
+	if meta.mode == rabbitModeMessageRate && meta.protocol != httpProtocol {
+		return fmt.Errorf("mode %s requires protocol %s", rabbitModeMessageRate, httpProtocol)
+	}
+
+	activation, err := config.GetActivationThreshold("activationValue", 0)
+	if err != nil {
+		return err
+	}
+	if _, ok := config.TriggerMetadata["activationValue"]; !ok && meta.mode == rabbitModeQueueLength {
+		// activationQueueLength precedes activationThreshold for compatibility
+		if activation, err = config.GetActivationThreshold("activationQueueLength", 0); err != nil {
+			return err
+		}
+	}
+	meta.activationValue = activation
+
+	return nil
+}
+
+// parseRabbitMQQueueSelection resolves whether queueName is a regex matching multiple queues
+// and how the values of these queues are aggregated
+func parseRabbitMQQueueSelection(config *ScalerConfig, meta *rabbitMQMetadata) error {
+	meta.operation = rabbitOperationSum
+	meta.pageSize = defaultRabbitMQPageSize
+
+	if val, ok := config.TriggerMetadata["useRegex"]; ok {
+		useRegex, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("useRegex has invalid value: %s", err)
+		}
+		meta.useRegex = useRegex
+	}
+
+	if val, ok := config.TriggerMetadata["operation"]; ok {
+		if !meta.useRegex {
+			return fmt.Errorf("operation can only be used together with useRegex")
+		}
+		switch rabbitMQOperation(val) {
+		case rabbitOperationSum, rabbitOperationMax:
+			meta.operation = rabbitMQOperation(val)
+		default:
+			return fmt.Errorf("the operation has to be either `%s` or `%s` but is `%s`", rabbitOperationSum, rabbitOperationMax, val)
+		}
+	}
+
+	if val, ok := config.TriggerMetadata["pageSize"]; ok {
+		pageSize, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("can't parse pageSize: %s", err)
+		}
+		if pageSize < 1 {
+			return fmt.Errorf("pageSize must be at least 1")
+		}
+		meta.pageSize = pageSize
+	}
+
+	if !meta.useRegex {
+		return nil
+	}
+	if meta.protocol != httpProtocol {
+		return fmt.Errorf("useRegex requires protocol %s", httpProtocol)
+	}
+	if _, err := regexp.Compile(meta.queueName); err != nil {
+		return fmt.Errorf("queueName is not a valid regex: %s", err)
+	}
+	return nil
+}
+
+// getRabbitMQAMQPHost returns the AMQP connection string with vhostName, if given, as its path
+func getRabbitMQAMQPHost(meta *rabbitMQMetadata) (string, error) {
+	if meta.vhostName == "" {
+		return meta.host, nil
+	}
+
+	parsedURL, err := url.Parse(meta.host)
+	if err != nil {
+		return "", err
+	}
+	parsedURL.Path = "/" + meta.vhostName
+	parsedURL.RawPath = "/" + url.PathEscape(meta.vhostName)
+	return parsedURL.String(), nil
+}
+
+// rabbitMQExternalAuth is the SASL EXTERNAL mechanism, the broker authenticates the client by its TLS certificate
+type rabbitMQExternalAuth struct{}
+
+func (auth *rabbitMQExternalAuth) Mechanism() string {
+	return "EXTERNAL"
+}
+
+func (auth *rabbitMQExternalAuth) Response() string {
+	return ""
+}
+
+func getConnectionAndChannel(host string, tlsConfig *tls.Config) (*amqp.Connection, *amqp.Channel, error) {
+	config := amqp.Config{
+		TLSClientConfig: tlsConfig,
+		// same defaults as amqp.Dial
+		Heartbeat: 10 * time.Second,
+		Locale:    "en_US",
+	}
+
+	// without credentials in the host, a client certificate authenticates the connection
+	if tlsConfig != nil && len(tlsConfig.Certificates) > 0 {
+		if parsedURL, err := url.Parse(host); err == nil && parsedURL.User == nil {
+			config.SASL = []amqp.Authentication{&rabbitMQExternalAuth{}}
+		}
+	}
+
+	conn, err := amqp.DialConfig(host, config)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -151,25 +387,55 @@ func (s *rabbitMQScaler) Close() error {
 
 // IsActive returns true if there are pending messages to be processed
 func (s *rabbitMQScaler) IsActive(ctx context.Context) (bool, error) {
-	messages, err := s.getQueueMessages()
+	value, err := s.getQueueValue()
 	if err != nil {
 		return false, fmt.Errorf("error inspecting rabbitMQ: %s", err)
 	}
 
-	return messages > 0, nil
+	return value > s.metadata.activationValue, nil
 }
 
-func (s *rabbitMQScaler) getQueueMessages() (int, error) {
-	if s.metadata.protocol == httpProtocol {
+// getQueueValue returns the queue length or the publish rate, depending on the mode,
+// aggregated over all matching queues if queueName is a regex
+func (s *rabbitMQScaler) getQueueValue() (float64, error) {
+	if s.metadata.protocol != httpProtocol {
+		messages, err := s.getQueueMessages()
+		return float64(messages), err
+	}
+
+	var queues []queueInfo
+	if s.metadata.useRegex {
+		var err error
+		queues, err = s.getQueuesInfoViaHTTP()
+		if err != nil {
+			return -1, err
+		}
+	} else {
 		info, err := s.getQueueInfoViaHTTP()
 		if err != nil {
 			return -1, err
 		}
+		queues = []queueInfo{*info}
+	}
 
+	result := 0.0
+	for _, info := range queues {
 		// messages count includes count of ready and unack-ed
-		return info.Messages, nil
+		value := float64(info.Messages)
+		if s.metadata.mode == rabbitModeMessageRate {
+			value = info.MessageStat.PublishDetail.Rate
+		}
+
+		if s.metadata.operation == rabbitOperationMax {
+			result = math.Max(result, value)
+		} else {
+			result += value
+		}
 	}
+	return result, nil
+}
 
+func (s *rabbitMQScaler) getQueueMessages() (int, error) {
 	items, err := s.channel.QueueInspect(s.metadata.queueName)
 	if err != nil {
 		return -1, err
@@ -178,9 +444,8 @@ func (s *rabbitMQScaler) getQueueMessages() (int, error) {
 	return items.Messages, nil
 }
 
-func getJSON(url string, target interface{}) error {
-	var client = &http.Client{Timeout: 5 * time.Second}
-	r, err := client.Get(url)
+func (s *rabbitMQScaler) getJSON(url string, target interface{}) error {
+	r, err := s.httpClient.Get(url)
 	if err != nil {
 		return err
 	}
@@ -194,25 +459,38 @@ func getJSON(url string, target interface{}) error {
 	return fmt.Errorf("error requesting rabbitMQ API status: %s, response: %s, from: %s", r.Status, body, url)
 }
 
-func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
+// getQueuesManagementURI returns the management API URI of the queues in the vhost, given either
+// by vhostName or by the path of host
+func (s *rabbitMQScaler) getQueuesManagementURI() (string, error) {
 	parsedURL, err := url.Parse(s.metadata.host)
 
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	vhost := parsedURL.Path
 
-	if vhost == "" || vhost == "/" || vhost == "//" {
+	if s.metadata.vhostName != "" {
+		vhost = "/" + url.PathEscape(s.metadata.vhostName)
+	} else if vhost == "" || vhost == "/" || vhost == "//" {
 		vhost = "/%2F"
 	}
 
 	parsedURL.Path = ""
 
-	getQueueInfoManagementURI := fmt.Sprintf("%s/%s%s/%s", parsedURL.String(), "api/queues", vhost, s.metadata.queueName)
+	return fmt.Sprintf("%s/%s%s", parsedURL.String(), "api/queues", vhost), nil
+}
+
+func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
+	queuesURI, err := s.getQueuesManagementURI()
+	if err != nil {
+		return nil, err
+	}
+
+	getQueueInfoManagementURI := fmt.Sprintf("%s/%s", queuesURI, s.metadata.queueName)
 
 	info := queueInfo{}
-	err = getJSON(getQueueInfoManagementURI, &info)
+	err = s.getJSON(getQueueInfoManagementURI, &info)
 
 	if err != nil {
 		return nil, err
@@ -221,12 +499,52 @@ func (s *rabbitMQScaler) getQueueInfoViaHTTP() (*queueInfo, error) {
 	return &info, nil
 }
 
+// getQueuesInfoViaHTTP returns all queues of the vhost whose name matches the queueName regex
+func (s *rabbitMQScaler) getQueuesInfoViaHTTP() ([]queueInfo, error) {
+	queuesURI, err := s.getQueuesManagementURI()
+	if err != nil {
+		return nil, err
+	}
+
+	queues := []queueInfo{}
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", strconv.Itoa(page))
+		query.Set("page_size", strconv.Itoa(s.metadata.pageSize))
+		query.Set("name", s.metadata.queueName)
+		query.Set("use_regex", "true")
+
+		result := queuesPage{}
+		if err := s.getJSON(fmt.Sprintf("%s?%s", queuesURI, query.Encode()), &result); err != nil {
+			return nil, err
+		}
+
+		queues = append(queues, result.Items...)
+		if page >= result.PageCount {
+			return queues, nil
+		}
+	}
+}
+
+// getMetricName returns the metric name of the queue, which is extended for the MessageRate mode,
+// and stripped of any characters not allowed in metric names when queueName is a regex
+func (s *rabbitMQScaler) getMetricName() string {
+	name := kedautil.NormalizeString(fmt.Sprintf("%s-%s", "rabbitmq", s.metadata.queueName))
+	if s.metadata.useRegex {
+		name = strings.Trim(rabbitMetricNameInvalidChars.ReplaceAllString(name, "-"), "-")
+	}
+	if s.metadata.mode == rabbitModeMessageRate {
+		name = fmt.Sprintf("%s-rate", name)
+	}
+	return name
+}
+
 // GetMetricSpecForScaling returns the MetricSpec for the Horizontal Pod Autoscaler
 func (s *rabbitMQScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
-	targetMetricValue := resource.NewQuantity(int64(s.metadata.queueLength), resource.DecimalSI)
+	targetMetricValue := resource.NewMilliQuantity(int64(s.metadata.value*1000), resource.DecimalSI)
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: kedautil.NormalizeString(fmt.Sprintf("%s-%s", "rabbitmq", s.metadata.queueName)),
+			Name: s.getMetricName(),
 		},
 		Target: v2beta2.MetricTarget{
 			Type:         v2beta2.AverageValueMetricType,
@@ -241,14 +559,14 @@ func (s *rabbitMQScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
 
 // GetMetrics returns value for a supported metric and an error if there is a problem getting the metric
 func (s *rabbitMQScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	messages, err := s.getQueueMessages()
+	value, err := s.getQueueValue()
 	if err != nil {
 		return []external_metrics.ExternalMetricValue{}, fmt.Errorf("error inspecting rabbitMQ: %s", err)
 	}
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(int64(messages), resource.DecimalSI),
+		Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 
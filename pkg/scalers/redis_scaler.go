@@ -4,7 +4,9 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
 
 	"github.com/go-redis/redis"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
@@ -25,7 +27,7 @@ const (
 
 type redisScaler struct {
 	metadata *redisMetadata
-	client   *redis.Client
+	client   redis.UniversalClient
 }
 
 type redisConnectionInfo struct {
@@ -34,43 +36,47 @@ type redisConnectionInfo struct {
 	host      string
 	port      string
 	enableTLS bool
+
+	// Redis Cluster and Sentinel
+	addresses        []string
+	sentinelPassword string
+	sentinelMaster   string
 }
 
+// redisAddressParser parses the connection info of a Redis topology (single node, cluster or sentinel)
+type redisAddressParser func(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error)
+
 type redisMetadata struct {
-	targetListLength int
-	listName         string
-	databaseIndex    int
-	connectionInfo   redisConnectionInfo
+	targetListLength     int
+	activationListLength float64
+	listName             string
+	databaseIndex        int
+	connectionInfo       redisConnectionInfo
 }
 
 var redisLog = logf.Log.WithName("redis_scaler")
 
-// NewRedisScaler creates a new redisScaler
-func NewRedisScaler(config *ScalerConfig) (Scaler, error) {
-	meta, err := parseRedisMetadata(config)
+// NewRedisScaler creates a new redisScaler for a single Redis node, a Redis Cluster or a Redis Sentinel setup
+func NewRedisScaler(isClustered, isSentinel bool, config *ScalerConfig) (Scaler, error) {
+	parseFn := getRedisAddressParser(isClustered, isSentinel)
+	meta, err := parseRedisMetadata(config, parseFn)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing redis metadata: %s", err)
 	}
-	options := &redis.Options{
-		Addr:     meta.connectionInfo.address,
-		Password: meta.connectionInfo.password,
-		DB:       meta.databaseIndex,
-	}
 
-	if meta.connectionInfo.enableTLS {
-		options.TLSConfig = &tls.Config{
-			InsecureSkipVerify: meta.connectionInfo.enableTLS,
-		}
+	client, err := getRedisClient(meta.connectionInfo, meta.databaseIndex, isClustered, isSentinel)
+	if err != nil {
+		return nil, fmt.Errorf("redis connection failed: %s", err)
 	}
 
 	return &redisScaler{
 		metadata: meta,
-		client:   redis.NewClient(options),
+		client:   client,
 	}, nil
 }
 
-func parseRedisMetadata(config *ScalerConfig) (*redisMetadata, error) {
-	connInfo, err := parseRedisAddress(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams)
+func parseRedisMetadata(config *ScalerConfig, parseFn redisAddressParser) (*redisMetadata, error) {
+	connInfo, err := parseFn(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +108,12 @@ func parseRedisMetadata(config *ScalerConfig) (*redisMetadata, error) {
 		meta.databaseIndex = int(dbIndex)
 	}
 
+	activation, err := config.GetActivationThreshold("activationListLength", 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationListLength = activation
+
 	return &meta, nil
 }
 
@@ -114,7 +126,7 @@ func (s *redisScaler) IsActive(ctx context.Context) (bool, error) {
 		return false, err
 	}
 
-	return length > 0, nil
+	return float64(length) > s.metadata.activationListLength, nil
 }
 
 func (s *redisScaler) Close() error {
@@ -165,7 +177,9 @@ func (s *redisScaler) GetMetrics(ctx context.Context, metricName string, metricS
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
 }
 
-func getRedisListLength(client *redis.Client, listName string) (int64, error) {
+// getRedisListLength evaluates the length of the list with a Lua script, in a Redis Cluster the script
+// is run on the node, which owns the hash slot of the list key
+func getRedisListLength(client redis.UniversalClient, listName string) (int64, error) {
 	luaScript := `
 		local listName = KEYS[1]
 		local listType = redis.call('type', listName).ok
@@ -222,6 +236,100 @@ func parseRedisAddress(metadata, resolvedEnv, authParams map[string]string) (red
 		return info, fmt.Errorf("no address or host given. address should be in the format of host:port or you should set the host/port values")
 	}
 
+	return info, parseRedisPasswordAndTLS(&info, metadata, resolvedEnv, authParams)
+}
+
+// parseRedisClusterAddress parses the seed addresses of the Redis Cluster nodes
+func parseRedisClusterAddress(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
+	info := redisConnectionInfo{}
+	addresses, err := parseRedisMultipleAddress(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return info, err
+	}
+	info.addresses = addresses
+
+	return info, parseRedisPasswordAndTLS(&info, metadata, resolvedEnv, authParams)
+}
+
+// parseRedisSentinelAddress parses the addresses of the sentinels, the name of the monitored master
+// and the credentials of the sentinels, password is the password of the master
+func parseRedisSentinelAddress(metadata, resolvedEnv, authParams map[string]string) (redisConnectionInfo, error) {
+	info := redisConnectionInfo{}
+	addresses, err := parseRedisMultipleAddress(metadata, resolvedEnv, authParams)
+	if err != nil {
+		return info, err
+	}
+	info.addresses = addresses
+
+	if authParams["sentinelMaster"] != "" {
+		info.sentinelMaster = authParams["sentinelMaster"]
+	} else if metadata["sentinelMaster"] != "" {
+		info.sentinelMaster = metadata["sentinelMaster"]
+	} else if metadata["sentinelMasterFromEnv"] != "" {
+		info.sentinelMaster = resolvedEnv[metadata["sentinelMasterFromEnv"]]
+	}
+	if len(info.sentinelMaster) == 0 {
+		return info, fmt.Errorf("no sentinel master given")
+	}
+
+	if authParams["sentinelPassword"] != "" {
+		info.sentinelPassword = authParams["sentinelPassword"]
+	} else if metadata["sentinelPasswordFromEnv"] != "" {
+		info.sentinelPassword = resolvedEnv[metadata["sentinelPasswordFromEnv"]]
+	}
+
+	return info, parseRedisPasswordAndTLS(&info, metadata, resolvedEnv, authParams)
+}
+
+// parseRedisMultipleAddress parses comma-separated addresses or comma-separated hosts and ports
+func parseRedisMultipleAddress(metadata, resolvedEnv, authParams map[string]string) ([]string, error) {
+	var addresses string
+	if authParams["addresses"] != "" {
+		addresses = authParams["addresses"]
+	} else if metadata["addresses"] != "" {
+		addresses = metadata["addresses"]
+	} else if metadata["addressesFromEnv"] != "" {
+		addresses = resolvedEnv[metadata["addressesFromEnv"]]
+	}
+	if addresses != "" {
+		return splitAndTrim(addresses), nil
+	}
+
+	var hosts, ports string
+	if authParams["hosts"] != "" {
+		hosts = authParams["hosts"]
+	} else if metadata["hosts"] != "" {
+		hosts = metadata["hosts"]
+	} else if metadata["hostsFromEnv"] != "" {
+		hosts = resolvedEnv[metadata["hostsFromEnv"]]
+	}
+
+	if authParams["ports"] != "" {
+		ports = authParams["ports"]
+	} else if metadata["ports"] != "" {
+		ports = metadata["ports"]
+	} else if metadata["portsFromEnv"] != "" {
+		ports = resolvedEnv[metadata["portsFromEnv"]]
+	}
+
+	if len(hosts) == 0 || len(ports) == 0 {
+		return nil, fmt.Errorf("no addresses or hosts given. addresses should be a comma separated list of host:port or you should set the hosts/ports values")
+	}
+
+	hostList := splitAndTrim(hosts)
+	portList := splitAndTrim(ports)
+	if len(hostList) != len(portList) {
+		return nil, fmt.Errorf("not enough hosts or ports given. number of hosts should be equal to the number of ports")
+	}
+
+	addressList := make([]string, len(hostList))
+	for i := range hostList {
+		addressList[i] = net.JoinHostPort(hostList[i], portList[i])
+	}
+	return addressList, nil
+}
+
+func parseRedisPasswordAndTLS(info *redisConnectionInfo, metadata, resolvedEnv, authParams map[string]string) error {
 	if authParams["password"] != "" {
 		info.password = authParams["password"]
 	} else if metadata["passwordFromEnv"] != "" {
@@ -232,10 +340,97 @@ func parseRedisAddress(metadata, resolvedEnv, authParams map[string]string) (red
 	if val, ok := metadata["enableTLS"]; ok {
 		tls, err := strconv.ParseBool(val)
 		if err != nil {
-			return info, fmt.Errorf("enableTLS parsing error %s", err.Error())
+			return fmt.Errorf("enableTLS parsing error %s", err.Error())
 		}
 		info.enableTLS = tls
 	}
 
-	return info, nil
+	return nil
+}
+
+func splitAndTrim(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func getRedisAddressParser(isClustered, isSentinel bool) redisAddressParser {
+	switch {
+	case isClustered:
+		return parseRedisClusterAddress
+	case isSentinel:
+		return parseRedisSentinelAddress
+	default:
+		return parseRedisAddress
+	}
+}
+
+// getRedisClient returns a client for the Redis topology, the connection is not verified
+func getRedisClient(info redisConnectionInfo, dbIndex int, isClustered, isSentinel bool) (redis.UniversalClient, error) {
+	var tlsConfig *tls.Config
+	if info.enableTLS {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	switch {
+	case isClustered:
+		// commands are routed to the node owning the hash slot of the key,
+		// Redis Cluster supports only the database 0
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     info.addresses,
+			Password:  info.password,
+			TLSConfig: tlsConfig,
+		}), nil
+	case isSentinel:
+		// the master is resolved when the scaler is built, after a failover the scaler fails
+		// and is rebuilt by the scalers cache, which resolves the new master
+		masterAddress, err := getRedisSentinelMasterAddress(info, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		return redis.NewClient(&redis.Options{
+			Addr:      masterAddress,
+			Password:  info.password,
+			DB:        dbIndex,
+			TLSConfig: tlsConfig,
+		}), nil
+	default:
+		return redis.NewClient(&redis.Options{
+			Addr:      info.address,
+			Password:  info.password,
+			DB:        dbIndex,
+			TLSConfig: tlsConfig,
+		}), nil
+	}
+}
+
+// getRedisSentinelMasterAddress asks the sentinels one by one for the address of the master
+func getRedisSentinelMasterAddress(info redisConnectionInfo, tlsConfig *tls.Config) (string, error) {
+	var lastErr error
+	for _, address := range info.addresses {
+		sentinel := redis.NewSentinelClient(&redis.Options{
+			Addr:      address,
+			Password:  info.sentinelPassword,
+			TLSConfig: tlsConfig,
+		})
+		result, err := sentinel.GetMasterAddrByName(info.sentinelMaster).Result()
+		sentinel.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if len(result) != 2 {
+			lastErr = fmt.Errorf("unexpected response %v from sentinel %s", result, address)
+			continue
+		}
+		return net.JoinHostPort(result[0], result[1]), nil
+	}
+
+	return "", fmt.Errorf("error getting address of master %s from sentinels: %s", info.sentinelMaster, lastErr)
 }
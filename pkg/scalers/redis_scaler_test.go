@@ -1,6 +1,7 @@
 package scalers
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/go-redis/redis"
@@ -56,7 +57,7 @@ var redisMetricIdentifiers = []redisMetricIdentifier{
 func TestRedisParseMetadata(t *testing.T) {
 	testCaseNum := 1
 	for _, testData := range testRedisMetadata {
-		_, err := parseRedisMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testRedisResolvedEnv, AuthParams: testData.authParams})
+		_, err := parseRedisMetadata(&ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: testRedisResolvedEnv, AuthParams: testData.authParams}, parseRedisAddress)
 		if err != nil && !testData.isError {
 			t.Errorf("Expected success but got error for unit test # %v", testCaseNum)
 		}
@@ -69,7 +70,7 @@ func TestRedisParseMetadata(t *testing.T) {
 
 func TestRedisGetMetricSpecForScaling(t *testing.T) {
 	for _, testData := range redisMetricIdentifiers {
-		meta, err := parseRedisMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: testRedisResolvedEnv, AuthParams: testData.metadataTestData.authParams})
+		meta, err := parseRedisMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: testRedisResolvedEnv, AuthParams: testData.metadataTestData.authParams}, parseRedisAddress)
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
@@ -82,3 +83,75 @@ func TestRedisGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRedisClusterMetadata(t *testing.T) {
+	cases := []struct {
+		name       string
+		metadata   map[string]string
+		authParams map[string]string
+		isError    bool
+		addresses  []string
+		password   string
+	}{
+		{"addresses", map[string]string{"listName": "mylist", "addresses": "a:1, b:2,c:3"}, nil, false, []string{"a:1", "b:2", "c:3"}, ""},
+		{"addresses from env", map[string]string{"listName": "mylist", "addressesFromEnv": "REDIS_ADDRESSES", "passwordFromEnv": "REDIS_PASSWORD"}, nil, false, []string{"a:1", "b:2"}, "none"},
+		{"hosts and ports", map[string]string{"listName": "mylist", "hosts": "a,b", "ports": "1,2"}, map[string]string{"password": "secret"}, false, []string{"a:1", "b:2"}, "secret"},
+		{"addresses in authParams", map[string]string{"listName": "mylist"}, map[string]string{"addresses": "a:1"}, false, []string{"a:1"}, ""},
+		{"hosts and ports mismatch", map[string]string{"listName": "mylist", "hosts": "a,b", "ports": "1"}, nil, true, nil, ""},
+		{"hosts without ports", map[string]string{"listName": "mylist", "hosts": "a,b"}, nil, true, nil, ""},
+		{"no addresses", map[string]string{"listName": "mylist"}, nil, true, nil, ""},
+	}
+
+	resolvedEnv := map[string]string{"REDIS_ADDRESSES": "a:1,b:2", "REDIS_PASSWORD": "none"}
+	for _, c := range cases {
+		meta, err := parseRedisMetadata(&ScalerConfig{TriggerMetadata: c.metadata, ResolvedEnv: resolvedEnv, AuthParams: c.authParams}, parseRedisClusterAddress)
+		if c.isError {
+			if err == nil {
+				t.Errorf("%s: expected error but got success", c.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: expected success but got error %s", c.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(meta.connectionInfo.addresses, c.addresses) {
+			t.Errorf("%s: expected addresses %v but got %v", c.name, c.addresses, meta.connectionInfo.addresses)
+		}
+		if meta.connectionInfo.password != c.password {
+			t.Errorf("%s: expected password %s but got %s", c.name, c.password, meta.connectionInfo.password)
+		}
+	}
+}
+
+func TestParseRedisSentinelMetadata(t *testing.T) {
+	resolvedEnv := map[string]string{"SENTINEL_MASTER": "mymaster", "SENTINEL_PASSWORD": "sentinel-secret"}
+
+	meta, err := parseRedisMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"listName": "mylist", "addresses": "s1:26379,s2:26379", "sentinelMasterFromEnv": "SENTINEL_MASTER", "sentinelPasswordFromEnv": "SENTINEL_PASSWORD"},
+		ResolvedEnv:     resolvedEnv,
+		AuthParams:      map[string]string{"password": "secret"},
+	}, parseRedisSentinelAddress)
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if meta.connectionInfo.sentinelMaster != "mymaster" || meta.connectionInfo.sentinelPassword != "sentinel-secret" || meta.connectionInfo.password != "secret" {
+		t.Errorf("Unexpected sentinel connection info %+v", meta.connectionInfo)
+	}
+
+	meta, err = parseRedisMetadata(&ScalerConfig{
+		TriggerMetadata: map[string]string{"listName": "mylist", "hosts": "s1", "ports": "26379", "sentinelMaster": "other"},
+		AuthParams:      map[string]string{"sentinelPassword": "auth-secret"},
+	}, parseRedisSentinelAddress)
+	if err != nil {
+		t.Fatal("Could not parse metadata:", err)
+	}
+	if meta.connectionInfo.sentinelMaster != "other" || meta.connectionInfo.sentinelPassword != "auth-secret" {
+		t.Errorf("Unexpected sentinel connection info %+v", meta.connectionInfo)
+	}
+
+	_, err = parseRedisMetadata(&ScalerConfig{TriggerMetadata: map[string]string{"listName": "mylist", "addresses": "s1:26379"}}, parseRedisSentinelAddress)
+	if err == nil {
+		t.Error("Expected error for missing sentinel master but got success")
+	}
+}
@@ -2,9 +2,10 @@ package scalers
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
 	"github.com/go-redis/redis"
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
@@ -21,9 +22,14 @@ const (
 	// defaults
 	defaultTargetPendingEntriesCount = 5
 	defaultDBIndex                   = 0
+	// the lag is counted up to the target for the default maxReplicaCount of a ScaledObject
+	defaultRedisStreamsMaxLagFactor = 100
 
 	// metadata names
 	pendingEntriesCountMetadata = "pendingEntriesCount"
+	streamLengthMetadata        = "streamLength"
+	lagCountMetadata            = "lagCount"
+	maxLagCountMetadata         = "maxLagCount"
 	streamNameMetadata          = "stream"
 	consumerGroupNameMetadata   = "consumerGroup"
 	passwordMetadata            = "password"
@@ -33,27 +39,46 @@ const (
 
 type redisStreamsScaler struct {
 	metadata *redisStreamsMetadata
-	conn     *redis.Client
+	conn     redis.UniversalClient
 }
 
+// redisStreamsScaleFactor is the value of the stream the scaler scales on
+type redisStreamsScaleFactor string
+
+const (
+	// xPendingFactor scales on the number of entries delivered to the consumer group, but not acknowledged
+	xPendingFactor redisStreamsScaleFactor = "pendingEntriesCount"
+	// xLengthFactor scales on the number of entries in the stream
+	xLengthFactor redisStreamsScaleFactor = "streamLength"
+	// lagFactor scales on the number of entries not yet delivered to the consumer group
+	lagFactor redisStreamsScaleFactor = "lagCount"
+)
+
+// the stream is read in pages when the lag has to be counted
+const redisStreamsLagPageSize = 1000
+
 type redisStreamsMetadata struct {
-	targetPendingEntriesCount int
-	streamName                string
-	consumerGroupName         string
-	databaseIndex             int
-	connectionInfo            redisConnectionInfo
+	scaleFactor       redisStreamsScaleFactor
+	targetCount       int
+	activationCount   float64
+	maxLagCount       int64
+	streamName        string
+	consumerGroupName string
+	databaseIndex     int
+	connectionInfo    redisConnectionInfo
 }
 
 var redisStreamsLog = logf.Log.WithName("redis_streams_scaler")
 
-// NewRedisStreamsScaler creates a new redisStreamsScaler
-func NewRedisStreamsScaler(config *ScalerConfig) (Scaler, error) {
-	meta, err := parseRedisStreamsMetadata(config)
+// NewRedisStreamsScaler creates a new redisStreamsScaler for a single Redis node, a Redis Cluster or a Redis Sentinel setup
+func NewRedisStreamsScaler(isClustered, isSentinel bool, config *ScalerConfig) (Scaler, error) {
+	parseFn := getRedisAddressParser(isClustered, isSentinel)
+	meta, err := parseRedisStreamsMetadata(config, parseFn)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing redis streams metadata: %s", err)
 	}
 
-	c, err := getRedisConnection(meta)
+	c, err := getRedisConnection(meta, isClustered, isSentinel)
 	if err != nil {
 		return nil, fmt.Errorf("redis connection failed: %s", err)
 	}
@@ -64,48 +89,50 @@ func NewRedisStreamsScaler(config *ScalerConfig) (Scaler, error) {
 	}, nil
 }
 
-func getRedisConnection(metadata *redisStreamsMetadata) (*redis.Client, error) {
-	options := &redis.Options{
-		Addr:     metadata.connectionInfo.address,
-		Password: metadata.connectionInfo.password,
-		DB:       metadata.databaseIndex,
-	}
-
-	if metadata.connectionInfo.enableTLS {
-		options.TLSConfig = &tls.Config{
-			InsecureSkipVerify: true,
-		}
-	}
-
+func getRedisConnection(metadata *redisStreamsMetadata, isClustered, isSentinel bool) (redis.UniversalClient, error) {
 	// this does not guarantee successful connection
-	c := redis.NewClient(options)
+	c, err := getRedisClient(metadata.connectionInfo, metadata.databaseIndex, isClustered, isSentinel)
+	if err != nil {
+		return nil, err
+	}
 
 	// confirm if connected
-	err := c.Ping().Err()
+	err = c.Ping().Err()
 	if err != nil {
+		c.Close()
 		return nil, err
 	}
 	return c, nil
 }
 
-func parseRedisStreamsMetadata(config *ScalerConfig) (*redisStreamsMetadata, error) {
-	connInfo, err := parseRedisAddress(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams)
+func parseRedisStreamsMetadata(config *ScalerConfig, parseFn redisAddressParser) (*redisStreamsMetadata, error) {
+	connInfo, err := parseFn(config.TriggerMetadata, config.ResolvedEnv, config.AuthParams)
 	if err != nil {
 		return nil, err
 	}
 	meta := redisStreamsMetadata{
 		connectionInfo: connInfo,
 	}
-	meta.targetPendingEntriesCount = defaultTargetPendingEntriesCount
+	meta.targetCount = defaultTargetPendingEntriesCount
 
-	if val, ok := config.TriggerMetadata[pendingEntriesCountMetadata]; ok {
-		pendingEntriesCount, err := strconv.Atoi(val)
+	// exactly one of pendingEntriesCount, streamLength and lagCount selects the scale factor and its target
+	for _, factor := range []redisStreamsScaleFactor{xPendingFactor, xLengthFactor, lagFactor} {
+		val, ok := config.TriggerMetadata[string(factor)]
+		if !ok {
+			continue
+		}
+		if meta.scaleFactor != "" {
+			return nil, fmt.Errorf("only one of %s, %s and %s can be given", pendingEntriesCountMetadata, streamLengthMetadata, lagCountMetadata)
+		}
+		count, err := strconv.Atoi(val)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing pending entries count %v", err)
+			return nil, fmt.Errorf("error parsing %s %v", factor, err)
 		}
-		meta.targetPendingEntriesCount = pendingEntriesCount
-	} else {
-		return nil, fmt.Errorf("missing pending entries count")
+		meta.scaleFactor = factor
+		meta.targetCount = count
+	}
+	if meta.scaleFactor == "" {
+		return nil, fmt.Errorf("missing pending entries count, stream length or lag count")
 	}
 
 	if val, ok := config.TriggerMetadata[streamNameMetadata]; ok {
@@ -114,9 +141,10 @@ func parseRedisStreamsMetadata(config *ScalerConfig) (*redisStreamsMetadata, err
 		return nil, fmt.Errorf("missing redis stream name")
 	}
 
+	// the stream length doesn't depend on a consumer group
 	if val, ok := config.TriggerMetadata[consumerGroupNameMetadata]; ok {
 		meta.consumerGroupName = val
-	} else {
+	} else if meta.scaleFactor != xLengthFactor {
 		return nil, fmt.Errorf("missing redis stream consumer group name")
 	}
 
@@ -129,19 +157,48 @@ func parseRedisStreamsMetadata(config *ScalerConfig) (*redisStreamsMetadata, err
 		meta.databaseIndex = int(dbIndex)
 	}
 
+	if meta.scaleFactor == lagFactor {
+		meta.maxLagCount = int64(meta.targetCount) * defaultRedisStreamsMaxLagFactor
+		if val, ok := config.TriggerMetadata[maxLagCountMetadata]; ok {
+			maxLagCount, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s %v", maxLagCountMetadata, err)
+			}
+			if maxLagCount <= 0 {
+				return nil, fmt.Errorf("%s must be positive but is %d", maxLagCountMetadata, maxLagCount)
+			}
+			meta.maxLagCount = maxLagCount
+		}
+	}
+
+	activationKey := "activationPendingEntriesCount"
+	switch meta.scaleFactor {
+	case xLengthFactor:
+		activationKey = "activationStreamLength"
+	case lagFactor:
+		activationKey = "activationLagCount"
+	}
+	activation, err := config.GetActivationThreshold(activationKey, 0)
+	if err != nil {
+		return nil, err
+	}
+	meta.activationCount = activation
+
 	return &meta, nil
 }
 
-// IsActive checks if there are pending entries in the 'Pending Entries List' for consumer group of a stream
+// IsActive checks if there are pending entries in the 'Pending Entries List' for consumer group of a stream,
+// entries in the stream or entries not yet delivered to the consumer group, depending on the scale factor
 func (s *redisStreamsScaler) IsActive(ctx context.Context) (bool, error) {
-	count, err := s.getPendingEntriesCount()
+	// the lag is only counted until it passes the activation count
+	count, err := s.getCount(int64(s.metadata.activationCount) + 1)
 
 	if err != nil {
 		redisStreamsLog.Error(err, "error")
 		return false, err
 	}
 
-	return count > 0, nil
+	return float64(count) > s.metadata.activationCount, nil
 }
 
 func (s *redisStreamsScaler) Close() error {
@@ -150,32 +207,43 @@ func (s *redisStreamsScaler) Close() error {
 
 // GetMetricSpecForScaling returns the metric spec for the HPA
 func (s *redisStreamsScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
-	targetPendingEntriesCount := resource.NewQuantity(int64(s.metadata.targetPendingEntriesCount), resource.DecimalSI)
+	var metricName string
+	switch s.metadata.scaleFactor {
+	case xLengthFactor:
+		metricName = fmt.Sprintf("%s-%s-%s", "redis-streams", s.metadata.streamName, "length")
+	case lagFactor:
+		metricName = fmt.Sprintf("%s-%s-%s-%s", "redis-streams", s.metadata.streamName, s.metadata.consumerGroupName, "lag")
+	default:
+		metricName = fmt.Sprintf("%s-%s-%s", "redis-streams", s.metadata.streamName, s.metadata.consumerGroupName)
+	}
+
+	targetCount := resource.NewQuantity(int64(s.metadata.targetCount), resource.DecimalSI)
 	externalMetric := &v2beta2.ExternalMetricSource{
 		Metric: v2beta2.MetricIdentifier{
-			Name: kedautil.NormalizeString(fmt.Sprintf("%s-%s-%s", "redis-streams", s.metadata.streamName, s.metadata.consumerGroupName)),
+			Name: kedautil.NormalizeString(metricName),
 		},
 		Target: v2beta2.MetricTarget{
 			Type:         v2beta2.AverageValueMetricType,
-			AverageValue: targetPendingEntriesCount,
+			AverageValue: targetCount,
 		},
 	}
 	metricSpec := v2beta2.MetricSpec{External: externalMetric, Type: externalMetricType}
 	return []v2beta2.MetricSpec{metricSpec}
 }
 
-// GetMetrics fetches the number of pending entries for a consumer group in a stream
+// GetMetrics fetches the number of pending entries for a consumer group in a stream, the stream length or the consumer group lag,
+// unless Redis reports the lag (Redis 7) it is counted up to maxLagCount only
 func (s *redisStreamsScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
-	pendingEntriesCount, err := s.getPendingEntriesCount()
+	count, err := s.getCount(s.metadata.maxLagCount)
 
 	if err != nil {
-		redisStreamsLog.Error(err, "error fetching pending entries count")
+		redisStreamsLog.Error(err, "error fetching stream metric", "scaleFactor", s.metadata.scaleFactor)
 		return []external_metrics.ExternalMetricValue{}, err
 	}
 
 	metric := external_metrics.ExternalMetricValue{
 		MetricName: metricName,
-		Value:      *resource.NewQuantity(pendingEntriesCount, resource.DecimalSI),
+		Value:      *resource.NewQuantity(count, resource.DecimalSI),
 		Timestamp:  metav1.Now(),
 	}
 	return append([]external_metrics.ExternalMetricValue{}, metric), nil
@@ -188,3 +256,162 @@ func (s *redisStreamsScaler) getPendingEntriesCount() (int64, error) {
 	}
 	return pendingEntries.Count, nil
 }
+
+// getCount returns the value of the scale factor, the lag is counted up to limit only, the other values are exact
+func (s *redisStreamsScaler) getCount(limit int64) (int64, error) {
+	switch s.metadata.scaleFactor {
+	case xLengthFactor:
+		return s.conn.XLen(s.metadata.streamName).Result()
+	case lagFactor:
+		return s.getLagCount(limit)
+	default:
+		return s.getPendingEntriesCount()
+	}
+}
+
+// getLagCount returns the number of entries added to the stream after the last entry delivered to the consumer group,
+// Redis 7 reports the lag in XINFO GROUPS, for older versions or if Redis can't determine the lag (eg. after deletions)
+// the entries after the last-delivered-id are counted until limit is reached
+func (s *redisStreamsScaler) getLagCount(limit int64) (int64, error) {
+	cmd := redis.NewSliceCmd("xinfo", "groups", s.metadata.streamName)
+	if err := s.conn.Process(cmd); err != nil {
+		return -1, err
+	}
+	reply, err := cmd.Result()
+	if err != nil {
+		return -1, err
+	}
+
+	groups, err := parseRedisStreamsGroupsInfo(reply)
+	if err != nil {
+		return -1, err
+	}
+
+	group, ok := groups[s.metadata.consumerGroupName]
+	if !ok {
+		return -1, fmt.Errorf("consumer group %s not found in stream %s", s.metadata.consumerGroupName, s.metadata.streamName)
+	}
+	if lag, ok := group["lag"].(int64); ok {
+		return lag, nil
+	}
+
+	lastDeliveredID, ok := group["last-delivered-id"].(string)
+	if !ok {
+		return -1, fmt.Errorf("no last-delivered-id of consumer group %s in stream %s", s.metadata.consumerGroupName, s.metadata.streamName)
+	}
+	return s.countEntriesAfter(lastDeliveredID, limit)
+}
+
+// countEntriesAfter counts the entries of the stream with an ID greater than the given one up to limit, it stops paging
+// through the stream once limit is reached. If the ID is before the first entry, eg. the stream was trimmed, all entries count.
+func (s *redisStreamsScaler) countEntriesAfter(id string, limit int64) (int64, error) {
+	first, err := s.conn.XRangeN(s.metadata.streamName, "-", "+", 1).Result()
+	if err != nil {
+		return -1, err
+	}
+	if len(first) == 0 {
+		return 0, nil
+	}
+	before, err := isRedisStreamIDBefore(id, first[0].ID)
+	if err != nil {
+		return -1, err
+	}
+	if before {
+		return s.conn.XLen(s.metadata.streamName).Result()
+	}
+
+	start, err := nextRedisStreamID(id)
+	if err != nil {
+		return -1, err
+	}
+
+	count := int64(0)
+	for count < limit {
+		entries, err := s.conn.XRangeN(s.metadata.streamName, start, "+", redisStreamsLagPageSize).Result()
+		if err != nil {
+			return -1, err
+		}
+		count += int64(len(entries))
+		if len(entries) < redisStreamsLagPageSize {
+			break
+		}
+		start, err = nextRedisStreamID(entries[len(entries)-1].ID)
+		if err != nil {
+			return -1, err
+		}
+	}
+	if count > limit {
+		count = limit
+	}
+	return count, nil
+}
+
+// parseRedisStreamsGroupsInfo converts the reply of XINFO GROUPS, a list of field-value lists, into maps by group name
+func parseRedisStreamsGroupsInfo(reply []interface{}) (map[string]map[string]interface{}, error) {
+	groups := make(map[string]map[string]interface{}, len(reply))
+	for _, item := range reply {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields)%2 != 0 {
+			return nil, fmt.Errorf("unexpected XINFO GROUPS reply %v", item)
+		}
+
+		group := make(map[string]interface{}, len(fields)/2)
+		for i := 0; i < len(fields); i += 2 {
+			key, ok := fields[i].(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected XINFO GROUPS field %v", fields[i])
+			}
+			group[key] = fields[i+1]
+		}
+
+		name, ok := group["name"].(string)
+		if !ok {
+			return nil, fmt.Errorf("no name in XINFO GROUPS reply %v", item)
+		}
+		groups[name] = group
+	}
+	return groups, nil
+}
+
+// parseRedisStreamID splits a stream ID of the form <ms>-<seq>
+func parseRedisStreamID(id string) (uint64, uint64, error) {
+	parts := strings.SplitN(id, "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid stream id %s", id)
+	}
+	ms, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stream id %s: %s", id, err)
+	}
+	seq, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stream id %s: %s", id, err)
+	}
+	return ms, seq, nil
+}
+
+// isRedisStreamIDBefore returns whether the stream ID a is smaller than b
+func isRedisStreamIDBefore(a, b string) (bool, error) {
+	aMs, aSeq, err := parseRedisStreamID(a)
+	if err != nil {
+		return false, err
+	}
+	bMs, bSeq, err := parseRedisStreamID(b)
+	if err != nil {
+		return false, err
+	}
+	return aMs < bMs || (aMs == bMs && aSeq < bSeq), nil
+}
+
+// nextRedisStreamID returns the smallest stream ID greater than the given one
+func nextRedisStreamID(id string) (string, error) {
+	ms, seq, err := parseRedisStreamID(id)
+	if err != nil {
+		return "", err
+	}
+
+	if seq == math.MaxUint64 {
+		return fmt.Sprintf("%d-0", ms+1), nil
+	}
+	return fmt.Sprintf("%d-%d", ms, seq+1), nil
+}
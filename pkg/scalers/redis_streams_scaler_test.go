@@ -43,11 +43,11 @@ func TestParseRedisStreamsMetadata(t *testing.T) {
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(te *testing.T) {
-			m, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, ResolvedEnv: tc.resolvedEnv, AuthParams: tc.authParams})
+			m, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, ResolvedEnv: tc.resolvedEnv, AuthParams: tc.authParams}, parseRedisAddress)
 			assert.Nil(t, err)
 			assert.Equal(t, m.streamName, tc.metadata[streamNameMetadata])
 			assert.Equal(t, m.consumerGroupName, tc.metadata[consumerGroupNameMetadata])
-			assert.Equal(t, strconv.Itoa(m.targetPendingEntriesCount), tc.metadata[pendingEntriesCountMetadata])
+			assert.Equal(t, strconv.Itoa(m.targetCount), tc.metadata[pendingEntriesCountMetadata])
 			if authParams != nil {
 				//if authParam is used
 				assert.Equal(t, m.connectionInfo.password, authParams[passwordMetadata])
@@ -101,7 +101,7 @@ func TestParseRedisStreamsMetadataForInvalidCases(t *testing.T) {
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(te *testing.T) {
-			_, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, ResolvedEnv: tc.resolvedEnv, AuthParams: map[string]string{}})
+			_, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, ResolvedEnv: tc.resolvedEnv, AuthParams: map[string]string{}}, parseRedisAddress)
 			assert.NotNil(t, err)
 		})
 	}
@@ -130,7 +130,7 @@ func TestRedisStreamsGetMetricSpecForScaling(t *testing.T) {
 	}
 
 	for _, testData := range redisStreamMetricIdentifiers {
-		meta, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: map[string]string{"REDIS_SERVICE": "my-address"}, AuthParams: testData.metadataTestData.authParams})
+		meta, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: testData.metadataTestData.metadata, ResolvedEnv: map[string]string{"REDIS_SERVICE": "my-address"}, AuthParams: testData.metadataTestData.authParams}, parseRedisAddress)
 		if err != nil {
 			t.Fatal("Could not parse metadata:", err)
 		}
@@ -143,3 +143,135 @@ func TestRedisStreamsGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRedisClusterStreamsMetadata(t *testing.T) {
+	metadata := map[string]string{"stream": "my-stream", "consumerGroup": "my-stream-consumer-group", "pendingEntriesCount": "5", "hosts": "a,b", "ports": "7000,7001"}
+	m, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: metadata, AuthParams: map[string]string{"password": "foobarred"}}, parseRedisClusterAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a:7000", "b:7001"}, m.connectionInfo.addresses)
+	assert.Equal(t, "foobarred", m.connectionInfo.password)
+
+	_, err = parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: metadata}, parseRedisSentinelAddress)
+	assert.NotNil(t, err)
+}
+
+func TestParseRedisStreamsScaleFactor(t *testing.T) {
+	type testCase struct {
+		name            string
+		metadata        map[string]string
+		isError         bool
+		scaleFactor     redisStreamsScaleFactor
+		targetCount     int
+		activationCount float64
+	}
+
+	testCases := []testCase{
+		{"pending entries", map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "pendingEntriesCount": "5", "activationPendingEntriesCount": "2", "address": "redis:6379"}, false, xPendingFactor, 5, 2},
+		{"stream length without consumer group", map[string]string{"stream": "my-stream", "streamLength": "20", "activationStreamLength": "3", "address": "redis:6379"}, false, xLengthFactor, 20, 3},
+		{"lag", map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "lagCount": "10", "activationLagCount": "1", "address": "redis:6379"}, false, lagFactor, 10, 1},
+		{"lag without consumer group", map[string]string{"stream": "my-stream", "lagCount": "10", "address": "redis:6379"}, true, "", 0, 0},
+		{"pending entries and lag", map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "pendingEntriesCount": "5", "lagCount": "10", "address": "redis:6379"}, true, "", 0, 0},
+		{"invalid lag", map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "lagCount": "junk", "address": "redis:6379"}, true, "", 0, 0},
+		{"invalid activation stream length", map[string]string{"stream": "my-stream", "streamLength": "20", "activationStreamLength": "junk", "address": "redis:6379"}, true, "", 0, 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(te *testing.T) {
+			m, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: tc.metadata, AuthParams: map[string]string{}}, parseRedisAddress)
+			if tc.isError {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.scaleFactor, m.scaleFactor)
+			assert.Equal(t, tc.targetCount, m.targetCount)
+			assert.Equal(t, tc.activationCount, m.activationCount)
+		})
+	}
+}
+
+func TestParseRedisStreamsMaxLagCount(t *testing.T) {
+	metadata := map[string]string{"stream": "my-stream", "consumerGroup": "my-group", "lagCount": "10", "address": "redis:6379"}
+	m, err := parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: metadata}, parseRedisAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000), m.maxLagCount)
+
+	metadata["maxLagCount"] = "50000"
+	m, err = parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: metadata}, parseRedisAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(50000), m.maxLagCount)
+
+	for _, invalid := range []string{"0", "junk"} {
+		metadata["maxLagCount"] = invalid
+		_, err = parseRedisStreamsMetadata(&ScalerConfig{TriggerMetadata: metadata}, parseRedisAddress)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestRedisStreamsMetricNames(t *testing.T) {
+	metadata := map[string]*redisStreamsMetadata{
+		"redis-streams-my-stream-my-group":     {scaleFactor: xPendingFactor, streamName: "my-stream", consumerGroupName: "my-group"},
+		"redis-streams-my-stream-length":       {scaleFactor: xLengthFactor, streamName: "my-stream"},
+		"redis-streams-my-stream-my-group-lag": {scaleFactor: lagFactor, streamName: "my-stream", consumerGroupName: "my-group"},
+	}
+
+	for name, meta := range metadata {
+		scaler := redisStreamsScaler{meta, nil}
+		assert.Equal(t, name, scaler.GetMetricSpecForScaling()[0].External.Metric.Name)
+	}
+}
+
+func TestParseRedisStreamsGroupsInfo(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{"name", "group-1", "consumers", int64(2), "pending", int64(3), "last-delivered-id", "1600000000000-5"},
+		[]interface{}{"name", "group-2", "consumers", int64(1), "pending", int64(0), "last-delivered-id", "0-0", "entries-read", nil, "lag", int64(7)},
+	}
+
+	groups, err := parseRedisStreamsGroupsInfo(reply)
+	assert.Nil(t, err)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "1600000000000-5", groups["group-1"]["last-delivered-id"])
+	_, hasLag := groups["group-1"]["lag"].(int64)
+	assert.False(t, hasLag)
+	assert.Equal(t, int64(7), groups["group-2"]["lag"])
+
+	_, err = parseRedisStreamsGroupsInfo([]interface{}{[]interface{}{"name"}})
+	assert.NotNil(t, err)
+}
+
+func TestNextRedisStreamID(t *testing.T) {
+	id, err := nextRedisStreamID("1600000000000-5")
+	assert.Nil(t, err)
+	assert.Equal(t, "1600000000000-6", id)
+
+	id, err = nextRedisStreamID("0-0")
+	assert.Nil(t, err)
+	assert.Equal(t, "0-1", id)
+
+	id, err = nextRedisStreamID("5-18446744073709551615")
+	assert.Nil(t, err)
+	assert.Equal(t, "6-0", id)
+
+	_, err = nextRedisStreamID("junk")
+	assert.NotNil(t, err)
+}
+
+func TestIsRedisStreamIDBefore(t *testing.T) {
+	for _, tc := range []struct {
+		a, b   string
+		before bool
+	}{
+		{"0-0", "1600000000000-0", true},
+		{"1600000000000-5", "1600000000000-6", true},
+		{"1600000000000-6", "1600000000000-6", false},
+		{"1600000000001-0", "1600000000000-6", false},
+	} {
+		before, err := isRedisStreamIDBefore(tc.a, tc.b)
+		assert.Nil(t, err)
+		assert.Equal(t, tc.before, before, "%s before %s", tc.a, tc.b)
+	}
+
+	_, err := isRedisStreamIDBefore("junk", "0-0")
+	assert.NotNil(t, err)
+}
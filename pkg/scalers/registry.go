@@ -0,0 +1,167 @@
+package scalers
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// scalerDefinition holds the functions of a trigger type, parse validates the trigger metadata the same way
+// build does, but it doesn't connect to the scaled source
+type scalerDefinition struct {
+	parse func(config *ScalerConfig) error
+	build func(config *ScalerConfig) (Scaler, error)
+}
+
+// scalerDefinitions maps the trigger types to their scalers, keep the trigger types sorted
+var scalerDefinitions = map[string]scalerDefinition{
+	// TRIGGERS-START
+	"artemis-queue": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseArtemisMetadata(c); return },
+		build: NewArtemisQueueScaler,
+	},
+	"aws-cloudwatch": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseAwsCloudwatchMetadata(c); return },
+		build: NewAwsCloudwatchScaler,
+	},
+	"aws-kinesis-stream": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseAwsKinesisStreamMetadata(c); return },
+		build: NewAwsKinesisStreamScaler,
+	},
+	"aws-sqs-queue": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseAwsSqsQueueMetadata(c); return },
+		build: NewAwsSqsQueueScaler,
+	},
+	"azure-blob": {
+		parse: func(c *ScalerConfig) (err error) { _, _, err = parseAzureBlobMetadata(c); return },
+		build: NewAzureBlobScaler,
+	},
+	"azure-eventhub": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseAzureEventHubMetadata(c); return },
+		build: NewAzureEventHubScaler,
+	},
+	"azure-log-analytics": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseAzureLogAnalyticsMetadata(c); return },
+		build: NewAzureLogAnalyticsScaler,
+	},
+	"azure-monitor": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseAzureMonitorMetadata(c); return },
+		build: NewAzureMonitorScaler,
+	},
+	"azure-queue": {
+		parse: func(c *ScalerConfig) (err error) { _, _, err = parseAzureQueueMetadata(c); return },
+		build: NewAzureQueueScaler,
+	},
+	"azure-servicebus": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseAzureServiceBusMetadata(c); return },
+		build: NewAzureServiceBusScaler,
+	},
+	"cpu": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseResourceMetadata(c); return },
+		build: func(c *ScalerConfig) (Scaler, error) { return NewCPUMemoryScaler(v1.ResourceCPU, c) },
+	},
+	"cron": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseCronMetadata(c); return },
+		build: NewCronScaler,
+	},
+	"external": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseExternalScalerMetadata(c); return },
+		build: NewExternalScaler,
+	},
+	"external-push": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseExternalScalerMetadata(c); return },
+		build: func(c *ScalerConfig) (Scaler, error) { return NewExternalPushScaler(c) },
+	},
+	"gcp-pubsub": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parsePubSubMetadata(c); return },
+		build: NewPubSubScaler,
+	},
+	"gcp-stackdriver": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseStackdriverMetadata(c); return },
+		build: NewStackdriverScaler,
+	},
+	"huawei-cloudeye": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseHuaweiCloudeyeMetadata(c); return },
+		build: NewHuaweiCloudeyeScaler,
+	},
+	"ibmmq": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseIBMMQMetadata(c); return },
+		build: NewIBMMQScaler,
+	},
+	"kafka": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseKafkaMetadata(c); return },
+		build: NewKafkaScaler,
+	},
+	"liiklus": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseLiiklusMetadata(c); return },
+		build: NewLiiklusScaler,
+	},
+	"memory": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseResourceMetadata(c); return },
+		build: func(c *ScalerConfig) (Scaler, error) { return NewCPUMemoryScaler(v1.ResourceMemory, c) },
+	},
+	"metrics-api": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseMetricsAPIMetadata(c); return },
+		build: NewMetricsAPIScaler,
+	},
+	"mysql": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseMySQLMetadata(c); return },
+		build: NewMySQLScaler,
+	},
+	"postgresql": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parsePostgreSQLMetadata(c); return },
+		build: NewPostgreSQLScaler,
+	},
+	"prometheus": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parsePrometheusMetadata(c); return },
+		build: NewPrometheusScaler,
+	},
+	"rabbitmq": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseRabbitMQMetadata(c); return },
+		build: NewRabbitMQScaler,
+	},
+	"redis": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseRedisMetadata(c, parseRedisAddress); return },
+		build: func(c *ScalerConfig) (Scaler, error) { return NewRedisScaler(false, false, c) },
+	},
+	"redis-cluster": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseRedisMetadata(c, parseRedisClusterAddress); return },
+		build: func(c *ScalerConfig) (Scaler, error) { return NewRedisScaler(true, false, c) },
+	},
+	"redis-cluster-streams": {
+		parse: func(c *ScalerConfig) (err error) {
+			_, err = parseRedisStreamsMetadata(c, parseRedisClusterAddress)
+			return
+		},
+		build: func(c *ScalerConfig) (Scaler, error) { return NewRedisStreamsScaler(true, false, c) },
+	},
+	"redis-sentinel": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseRedisMetadata(c, parseRedisSentinelAddress); return },
+		build: func(c *ScalerConfig) (Scaler, error) { return NewRedisScaler(false, true, c) },
+	},
+	"redis-sentinel-streams": {
+		parse: func(c *ScalerConfig) (err error) {
+			_, err = parseRedisStreamsMetadata(c, parseRedisSentinelAddress)
+			return
+		},
+		build: func(c *ScalerConfig) (Scaler, error) { return NewRedisStreamsScaler(false, true, c) },
+	},
+	"redis-streams": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseRedisStreamsMetadata(c, parseRedisAddress); return },
+		build: func(c *ScalerConfig) (Scaler, error) { return NewRedisStreamsScaler(false, false, c) },
+	},
+	"stan": {
+		parse: func(c *ScalerConfig) (err error) { _, err = parseStanMetadata(c); return },
+		build: NewStanScaler,
+	},
+	// TRIGGERS-END
+}
+
+// BuildScaler creates the scaler of the trigger type with the given config
+func BuildScaler(triggerType string, config *ScalerConfig) (Scaler, error) {
+	definition, ok := scalerDefinitions[triggerType]
+	if !ok {
+		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
+	}
+	return definition.build(config)
+}
@@ -2,14 +2,21 @@ package scalers
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
 	v2beta2 "k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/metrics/pkg/apis/external_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
 )
 
+// activationThresholdMetadata is the common trigger metadata key for the activation threshold of any scaler
+const activationThresholdMetadata = "activationThreshold"
+
 // Scaler interface
 type Scaler interface {
 
@@ -53,4 +60,30 @@ type ScalerConfig struct {
 
 	// PodIdentity
 	PodIdentity kedav1alpha1.PodIdentityProvider
+
+	// KubeClient reads Kubernetes objects in Namespace, which configure a scaler at runtime (eg. ConfigMaps),
+	// the objects are read from the API server without starting an informer for their type
+	KubeClient client.Reader `hash:"ignore"`
+
+	// PollingInterval is the interval the scaler is checked at, objects read by KubeClient are cached for this interval
+	PollingInterval time.Duration
+}
+
+// GetActivationThreshold parses the activation threshold of a scaler from the trigger metadata.
+// The scaler specific key (eg. `activationLagThreshold`) takes precedence over the common `activationThreshold`,
+// the scaler is considered active only when its metric value is greater than the threshold.
+func (c *ScalerConfig) GetActivationThreshold(key string, defaultValue float64) (float64, error) {
+	for _, k := range []string{key, activationThresholdMetadata} {
+		if val, ok := c.TriggerMetadata[k]; ok && val != "" {
+			threshold, err := strconv.ParseFloat(val, 64)
+			if err != nil {
+				return 0, fmt.Errorf("error parsing %s: %s", k, err)
+			}
+			if threshold < 0 {
+				return 0, fmt.Errorf("%s must not be negative", k)
+			}
+			return threshold, nil
+		}
+	}
+	return defaultValue, nil
 }
@@ -0,0 +1,46 @@
+package scalers
+
+import (
+	"testing"
+)
+
+type activationThresholdTestData struct {
+	metadata     map[string]string
+	defaultValue float64
+	isError      bool
+	threshold    float64
+}
+
+var testActivationThresholds = []activationThresholdTestData{
+	// not specified, default value
+	{map[string]string{}, 0, false, 0},
+	{map[string]string{}, 2.5, false, 2.5},
+	// scaler specific key
+	{map[string]string{"activationLagThreshold": "10"}, 0, false, 10},
+	// common key
+	{map[string]string{"activationThreshold": "0.5"}, 0, false, 0.5},
+	// scaler specific key takes precedence
+	{map[string]string{"activationLagThreshold": "10", "activationThreshold": "5"}, 0, false, 10},
+	// empty value, default value
+	{map[string]string{"activationLagThreshold": ""}, 1, false, 1},
+	// invalid value
+	{map[string]string{"activationLagThreshold": "a"}, 0, true, 0},
+	// negative value
+	{map[string]string{"activationThreshold": "-1"}, 0, true, 0},
+}
+
+func TestGetActivationThreshold(t *testing.T) {
+	for _, testData := range testActivationThresholds {
+		config := &ScalerConfig{TriggerMetadata: testData.metadata}
+		threshold, err := config.GetActivationThreshold("activationLagThreshold", testData.defaultValue)
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success but got error for %v: %s", testData.metadata, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error but got success for %v", testData.metadata)
+		}
+		if !testData.isError && threshold != testData.threshold {
+			t.Errorf("Expected threshold %v but got %v for %v", testData.threshold, threshold, testData.metadata)
+		}
+	}
+}
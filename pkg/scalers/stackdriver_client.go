@@ -4,33 +4,96 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	monitoring "cloud.google.com/go/monitoring/apiv3"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/oauth2/google"
+	iamcredentials "google.golang.org/api/iamcredentials/v1"
 	"google.golang.org/api/iterator"
 	option "google.golang.org/api/option"
 	monitoringpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	"google.golang.org/grpc"
 )
 
-// StackDriverClient is a generic client to fetch metrics from Stackdriver. Can be used
-// for a stackdriver scaler in the future
+// StackDriverClient is a generic client to fetch metrics from Stackdriver (Cloud Monitoring)
 type StackDriverClient struct {
 	metricsClient *monitoring.MetricClient
 	credentials   GoogleApplicationCredentials
 }
 
-// NewStackDriverClient creates a new stackdriver client with the credentials that are passed
-func NewStackDriverClient(ctx context.Context, credentials string) (*StackDriverClient, error) {
+// NewStackDriverClient creates a new stackdriver client with the credentials that are passed. A custom endpoint
+// must be an http:// emulator endpoint, which is connected to without TLS and authentication, the credentials
+// are never sent to an endpoint given in the trigger metadata.
+func NewStackDriverClient(ctx context.Context, credentials string, endpoint string) (*StackDriverClient, error) {
 	var gcpCredentials GoogleApplicationCredentials
+	var clientOptions []option.ClientOption
+
+	if endpoint != "" {
+		if err := validateStackDriverEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+		// the credentials are optional, they may still provide the project
+		if credentials != "" {
+			if err := json.Unmarshal([]byte(credentials), &gcpCredentials); err != nil {
+				return nil, err
+			}
+		}
+		clientOptions = getStackDriverEmulatorOptions(endpoint)
+	} else {
+		if err := json.Unmarshal([]byte(credentials), &gcpCredentials); err != nil {
+			return nil, err
+		}
+		clientOptions = append(clientOptions, option.WithCredentialsJSON([]byte(credentials)))
+	}
 
-	if err := json.Unmarshal([]byte(credentials), &gcpCredentials); err != nil {
+	client, err := monitoring.NewMetricClient(ctx, clientOptions...)
+	if err != nil {
 		return nil, err
 	}
 
-	clientOption := option.WithCredentialsJSON([]byte(credentials))
+	return &StackDriverClient{
+		metricsClient: client,
+		credentials:   gcpCredentials,
+	}, nil
+}
+
+// NewStackDriverClientPodIdentity creates a new stackdriver client with the application default credentials, ie. the
+// GKE metadata server with workload identity or the GOOGLE_APPLICATION_CREDENTIALS of the KEDA operator. The project
+// of these credentials is used by default, access tokens of impersonateServiceAccount are used if it is not empty.
+// The credentials of the operator are only sent to the Cloud Monitoring API, a custom endpoint must be an http://
+// emulator endpoint, which is connected to without authentication.
+func NewStackDriverClientPodIdentity(ctx context.Context, impersonateServiceAccount string, endpoint string) (*StackDriverClient, error) {
+	var gcpCredentials GoogleApplicationCredentials
+	var clientOptions []option.ClientOption
+
+	if endpoint != "" {
+		if err := validateStackDriverEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+		clientOptions = getStackDriverEmulatorOptions(endpoint)
+	} else {
+		// the credentials are refreshed after ctx is done, so they must not depend on it
+		creds, err := google.FindDefaultCredentials(context.Background(), monitoring.DefaultAuthScopes()...)
+		if err != nil {
+			return nil, fmt.Errorf("error finding application default credentials: %s", err)
+		}
+		gcpCredentials.ProjectID = creds.ProjectID
+
+		tokenSource := creds.TokenSource
+		if impersonateServiceAccount != "" {
+			iamService, err := iamcredentials.NewService(context.Background(), option.WithTokenSource(creds.TokenSource))
+			if err != nil {
+				return nil, err
+			}
+			tokenSource = newGcpImpersonatedTokenSource(iamService, impersonateServiceAccount, monitoring.DefaultAuthScopes())
+		}
+
+		clientOptions = append(clientOptions, option.WithTokenSource(tokenSource))
+	}
 
-	client, err := monitoring.NewMetricClient(ctx, clientOption)
+	client, err := monitoring.NewMetricClient(ctx, clientOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -41,17 +104,47 @@ func NewStackDriverClient(ctx context.Context, credentials string) (*StackDriver
 	}, nil
 }
 
-// GetMetrics fetches metrics from stackdriver for a specific filter for the last minute
-func (s StackDriverClient) GetMetrics(ctx context.Context, filter string) (int64, error) {
-	// Set the start time to 1 minute ago
-	startTime := time.Now().UTC().Add(time.Minute * -2)
+// validateStackDriverEndpoint only accepts emulator endpoints, tokens minted from the credentials of a
+// TriggerAuthentication or the operator must not be sent to a host chosen in the trigger metadata
+func validateStackDriverEndpoint(endpoint string) error {
+	if endpoint != "" && !strings.HasPrefix(endpoint, "http://") {
+		return fmt.Errorf("endpoint must be an unauthenticated http:// emulator endpoint but is %s", endpoint)
+	}
+	return nil
+}
 
-	// Set the end time to now
+// getStackDriverEmulatorOptions returns the options to connect to an emulator at the http:// endpoint
+func getStackDriverEmulatorOptions(endpoint string) []option.ClientOption {
+	return []option.ClientOption{
+		option.WithEndpoint(strings.TrimPrefix(endpoint, "http://")),
+		option.WithoutAuthentication(),
+		option.WithGRPCDialOption(grpc.WithInsecure()),
+	}
+}
+
+// GetMetrics fetches the latest value of the first time series matching the filter in the project, the project of the
+// credentials is used if projectID is empty. The window of the query covers the last two minutes, or the alignment
+// period of the aggregation if it is longer. A reducer should be used to combine filters matching multiple series.
+func (s StackDriverClient) GetMetrics(ctx context.Context, filter string, projectID string, aggregation *monitoringpb.Aggregation) (float64, error) {
+	if projectID == "" {
+		projectID = s.credentials.ProjectID
+	}
+	if projectID == "" {
+		return -1, fmt.Errorf("no project given and the credentials don't contain a project_id")
+	}
+
+	window := 2 * time.Minute
+	if period := time.Duration(aggregation.GetAlignmentPeriod().GetSeconds()) * time.Second; period > window {
+		window = period
+	}
+
+	// Set the end time to now and the start time to the beginning of the window
 	endTime := time.Now().UTC()
+	startTime := endTime.Add(-window)
 
 	// Create a request with the filter and the GCP project ID
 	req := &monitoringpb.ListTimeSeriesRequest{
-		Name:   "projects/" + s.credentials.ProjectID,
+		Name:   "projects/" + projectID,
 		Filter: filter,
 		Interval: &monitoringpb.TimeInterval{
 			StartTime: &timestamp.Timestamp{
@@ -61,12 +154,13 @@ func (s StackDriverClient) GetMetrics(ctx context.Context, filter string) (int64
 				Seconds: endTime.Unix(),
 			},
 		},
+		Aggregation: aggregation,
 	}
 
 	// Get an iterator with the list of time series
 	it := s.metricsClient.ListTimeSeries(ctx, req)
 
-	var value int64 = -1
+	var value float64 = -1
 
 	// Get the value from the first metric returned
 	resp, err := it.Next()
@@ -79,14 +173,36 @@ func (s StackDriverClient) GetMetrics(ctx context.Context, filter string) (int64
 		return value, err
 	}
 
+	// the points are ordered by time, the latest point comes first
 	if len(resp.GetPoints()) > 0 {
-		point := resp.GetPoints()[0]
-		value = point.GetValue().GetInt64Value()
+		value, err = getTypedValue(resp.GetPoints()[0].GetValue())
+		if err != nil {
+			return -1, fmt.Errorf("error reading stackdriver metric with filter %s: %s", filter, err)
+		}
 	}
 
 	return value, nil
 }
 
+// getTypedValue returns numeric and boolean values as a number, and the mean of distributions
+func getTypedValue(value *monitoringpb.TypedValue) (float64, error) {
+	switch v := value.GetValue().(type) {
+	case *monitoringpb.TypedValue_Int64Value:
+		return float64(v.Int64Value), nil
+	case *monitoringpb.TypedValue_DoubleValue:
+		return v.DoubleValue, nil
+	case *monitoringpb.TypedValue_BoolValue:
+		if v.BoolValue {
+			return 1, nil
+		}
+		return 0, nil
+	case *monitoringpb.TypedValue_DistributionValue:
+		return v.DistributionValue.GetMean(), nil
+	default:
+		return 0, fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
 // GoogleApplicationCredentials is a struct representing the format of a service account
 // credentials file
 type GoogleApplicationCredentials struct {
@@ -49,6 +49,7 @@ type stanMetadata struct {
 	durableName                  string
 	subject                      string
 	lagThreshold                 int64
+	activationLagThreshold       float64
 }
 
 const (
@@ -104,6 +105,12 @@ func parseStanMetadata(config *ScalerConfig) (stanMetadata, error) {
 		meta.lagThreshold = t
 	}
 
+	activation, err := config.GetActivationThreshold("activationLagThreshold", 0)
+	if err != nil {
+		return meta, err
+	}
+	meta.activationLagThreshold = activation
+
 	return meta, nil
 }
 
@@ -135,7 +142,7 @@ func (s *stanScaler) IsActive(ctx context.Context) (bool, error) {
 	defer resp.Body.Close()
 	json.NewDecoder(resp.Body).Decode(&s.channelInfo)
 
-	return s.hasPendingMessage() || s.getMaxMsgLag() > 0, nil
+	return float64(s.getPendingCount()) > s.metadata.activationLagThreshold || float64(s.getMaxMsgLag()) > s.metadata.activationLagThreshold, nil
 }
 
 func (s *stanScaler) getSTANChannelsEndpoint() string {
@@ -159,27 +166,17 @@ func (s *stanScaler) getMaxMsgLag() int64 {
 	return s.channelInfo.LastSequence - maxValue
 }
 
-func (s *stanScaler) hasPendingMessage() bool {
-	subscriberFound := false
+func (s *stanScaler) getPendingCount() int64 {
 	combinedQueueName := s.metadata.durableName + ":" + s.metadata.queueGroup
 
 	for _, subs := range s.channelInfo.Subscriber {
 		if subs.QueueName == combinedQueueName {
-			subscriberFound = true
-
-			if subs.PendingCount > 0 {
-				return true
-			}
-
-			break
+			return int64(subs.PendingCount)
 		}
 	}
 
-	if !subscriberFound {
-		stanLog.Info("The STAN subscription was not found.", "combinedQueueName", combinedQueueName)
-	}
-
-	return false
+	stanLog.Info("The STAN subscription was not found.", "combinedQueueName", combinedQueueName)
+	return 0
 }
 
 func (s *stanScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
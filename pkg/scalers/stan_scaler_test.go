@@ -1,6 +1,10 @@
 package scalers
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -58,3 +62,36 @@ func TestStanGetMetricSpecForScaling(t *testing.T) {
 		}
 	}
 }
+
+func TestStanIsActive(t *testing.T) {
+	// 3 messages are pending and the queue group lags 5 messages behind the channel
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "mySubject", "last_seq": 10, "subscriptions": [{"queue_name": "ImDurable:grp1", "last_sent": 5, "pending_count": 3}]}`))
+	}))
+	defer server.Close()
+
+	var testCases = []struct {
+		activationLagThreshold string
+		isActive               bool
+	}{
+		{"0", true},
+		{"3", true},
+		{"5", false},
+		{"10", false},
+	}
+
+	for _, testCase := range testCases {
+		metadata := map[string]string{"natsServerMonitoringEndpoint": strings.TrimPrefix(server.URL, "http://"), "queueGroup": "grp1", "durableName": "ImDurable", "subject": "mySubject", "activationLagThreshold": testCase.activationLagThreshold}
+		s, err := NewStanScaler(&ScalerConfig{TriggerMetadata: metadata})
+		if err != nil {
+			t.Fatal("Could not create the scaler:", err)
+		}
+		isActive, err := s.IsActive(context.TODO())
+		if err != nil {
+			t.Fatal("Expected success but got error", err)
+		}
+		if isActive != testCase.isActive {
+			t.Errorf("activationLagThreshold %s: expected active %v but got %v", testCase.activationLagThreshold, testCase.isActive, isActive)
+		}
+	}
+}
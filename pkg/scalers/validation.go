@@ -0,0 +1,15 @@
+package scalers
+
+import (
+	"fmt"
+)
+
+// ValidateTriggerMetadata parses the metadata of a trigger the same way the scaler does when it is built,
+// but it doesn't connect to the scaled source. It is used to validate triggers before they are deployed.
+func ValidateTriggerMetadata(triggerType string, config *ScalerConfig) error {
+	definition, ok := scalerDefinitions[triggerType]
+	if !ok {
+		return fmt.Errorf("no scaler found for type: %s", triggerType)
+	}
+	return definition.parse(config)
+}
@@ -0,0 +1,46 @@
+package scalers
+
+import (
+	"testing"
+)
+
+type validateTriggerMetadataTestData struct {
+	triggerType string
+	metadata    map[string]string
+	isError     bool
+}
+
+var testValidateTriggerMetadata = []validateTriggerMetadataTestData{
+	{"cpu", map[string]string{"type": "Utilization", "value": "50"}, false},
+	{"cpu", map[string]string{"type": "AverageValue", "value": "a"}, true},
+	{"memory", map[string]string{"type": "Value"}, true},
+	{"kafka", map[string]string{"bootstrapServers": "localhost:9092", "consumerGroup": "my-group", "topic": "my-topic"}, false},
+	{"kafka", map[string]string{"bootstrapServers": "localhost:9092"}, true},
+	{"prometheus", map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "100", "query": "up"}, false},
+	{"prometheus", map[string]string{"serverAddress": "http://localhost:9090", "metricName": "http_requests_total", "threshold": "a", "query": "up"}, true},
+	{"unknown", map[string]string{}, true},
+}
+
+func TestValidateTriggerMetadata(t *testing.T) {
+	for _, testData := range testValidateTriggerMetadata {
+		err := ValidateTriggerMetadata(testData.triggerType, &ScalerConfig{TriggerMetadata: testData.metadata, ResolvedEnv: map[string]string{}, AuthParams: map[string]string{}})
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success for %s %v but got error: %s", testData.triggerType, testData.metadata, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error for %s %v but got success", testData.triggerType, testData.metadata)
+		}
+	}
+}
+
+func TestScalerDefinitions(t *testing.T) {
+	for triggerType, definition := range scalerDefinitions {
+		if definition.parse == nil || definition.build == nil {
+			t.Errorf("Expected parse and build functions for %s", triggerType)
+		}
+	}
+
+	if _, err := BuildScaler("unknown", &ScalerConfig{}); err == nil {
+		t.Error("Expected error for unknown trigger type but got success")
+	}
+}
@@ -0,0 +1,267 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+
+	"github.com/kedacore/keda/pkg/scalers"
+	"github.com/kedacore/keda/pkg/scaling/modifiers"
+)
+
+// ScalersCache holds live scalers built for a single ScalableObject (ScaledObject or ScaledJob).
+// The cache is valid as long as the object's Generation and the hash of the resolved
+// scalers configuration (trigger metadata, authentication and environment) don't change.
+// Every caller holding the cache must Release it, a closed cache closes its scalers once it is released by all holders.
+type ScalersCache struct {
+	Generation int64
+	ConfigHash uint64
+	Logger     logr.Logger
+	// Formula is the compiled scalingModifiers formula of a ScaledObject, nil if it has no scalingModifiers
+	Formula *modifiers.Formula
+
+	scalers []*cachedScaler
+	lock    sync.RWMutex
+
+	// refs is the number of callers holding the cache, closed is set once the cache is replaced or removed
+	refs    int
+	closed  bool
+	refLock sync.Mutex
+}
+
+// ScalerBuilder holds a scaler together with the factory that built it,
+// so the scaler could be rebuilt once it starts failing
+type ScalerBuilder struct {
+	Scaler  scalers.Scaler
+	Factory func() (scalers.Scaler, error)
+}
+
+// minScalerRebuildInterval limits how often a failing scaler is rebuilt, so an outage of the scaled service
+// doesn't open a new connection on every failing call
+var minScalerRebuildInterval = 30 * time.Second
+
+// cachedScaler is a scaler in the cache, a replaced scaler is closed once the calls in flight on it finish
+type cachedScaler struct {
+	scaler  scalers.Scaler
+	factory func() (scalers.Scaler, error)
+	// rebuiltAt is the time the scaler was built to replace a failing one, zero for the initial scaler
+	rebuiltAt time.Time
+
+	refs    int
+	retired bool
+	refLock sync.Mutex
+}
+
+// NewScalersCache creates a ScalersCache from already built scalers
+func NewScalersCache(generation int64, configHash uint64, logger logr.Logger, builders []ScalerBuilder) *ScalersCache {
+	cached := make([]*cachedScaler, 0, len(builders))
+	for _, b := range builders {
+		cached = append(cached, &cachedScaler{scaler: b.Scaler, factory: b.Factory})
+	}
+	return &ScalersCache{
+		Generation: generation,
+		ConfigHash: configHash,
+		Logger:     logger,
+		scalers:    cached,
+	}
+}
+
+// GetScalers returns the cached scalers, the returned scalers must not be closed by the caller
+func (c *ScalersCache) GetScalers() []scalers.Scaler {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := make([]scalers.Scaler, 0, len(c.scalers))
+	for _, s := range c.scalers {
+		result = append(result, s.scaler)
+	}
+	return result
+}
+
+// GetPushScalers returns the cached scalers that implement PushScaler interface
+func (c *ScalersCache) GetPushScalers() []scalers.PushScaler {
+	var result []scalers.PushScaler
+	for _, s := range c.GetScalers() {
+		if ps, ok := s.(scalers.PushScaler); ok {
+			result = append(result, ps)
+		}
+	}
+	return result
+}
+
+// IsScalerActive calls IsActive on the scaler with the specified index.
+// If the call fails, the scaler is rebuilt and the call is retried once.
+func (c *ScalersCache) IsScalerActive(ctx context.Context, index int) (bool, error) {
+	cs, err := c.acquireScaler(index)
+	if err != nil {
+		return false, err
+	}
+
+	isActive, err := cs.scaler.IsActive(ctx)
+	cs.release(c.Logger)
+	if err != nil {
+		ns, refreshErr := c.refreshScaler(index, cs)
+		if refreshErr != nil {
+			return false, err
+		}
+		defer ns.release(c.Logger)
+		return ns.scaler.IsActive(ctx)
+	}
+	return isActive, nil
+}
+
+// GetMetricsForScaler calls GetMetrics on the scaler with the specified index.
+// If the call fails, the scaler is rebuilt and the call is retried once.
+func (c *ScalersCache) GetMetricsForScaler(ctx context.Context, index int, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	cs, err := c.acquireScaler(index)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics, err := cs.scaler.GetMetrics(ctx, metricName, metricSelector)
+	cs.release(c.Logger)
+	if err != nil {
+		ns, refreshErr := c.refreshScaler(index, cs)
+		if refreshErr != nil {
+			return nil, err
+		}
+		defer ns.release(c.Logger)
+		return ns.scaler.GetMetrics(ctx, metricName, metricSelector)
+	}
+	return metrics, nil
+}
+
+// Acquire registers a caller holding the cache, it must be called while the cache can't be closed concurrently
+func (c *ScalersCache) Acquire() {
+	c.refLock.Lock()
+	defer c.refLock.Unlock()
+
+	c.refs++
+}
+
+// Release is called by a caller that no longer uses the cache, the scalers of a closed cache
+// are closed when the last holder releases it
+func (c *ScalersCache) Release() {
+	c.refLock.Lock()
+	defer c.refLock.Unlock()
+
+	c.refs--
+	if c.closed && c.refs == 0 {
+		c.closeScalers()
+	}
+}
+
+// Close closes all cached scalers as soon as no caller holds the cache
+func (c *ScalersCache) Close() {
+	c.refLock.Lock()
+	defer c.refLock.Unlock()
+
+	if c.closed {
+		return
+	}
+	c.closed = true
+	if c.refs == 0 {
+		c.closeScalers()
+	}
+}
+
+func (c *ScalersCache) closeScalers() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, s := range c.scalers {
+		s.retire(c.Logger)
+	}
+	c.scalers = nil
+}
+
+// acquireScaler returns the scaler with the specified index, the caller has to release it once its call finishes
+func (c *ScalersCache) acquireScaler(index int) (*cachedScaler, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if index < 0 || index >= len(c.scalers) {
+		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably invalidated", index, len(c.scalers))
+	}
+	cs := c.scalers[index]
+	cs.acquire()
+	return cs, nil
+}
+
+// refreshScaler replaces the failed scaler with the specified index with a new one built by its factory and returns
+// the new scaler acquired for the caller. If another caller has already replaced the failed scaler, its replacement
+// is returned. The failed scaler is closed once the calls in flight on it finish.
+func (c *ScalersCache) refreshScaler(index int, failed *cachedScaler) (*cachedScaler, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if index < 0 || index >= len(c.scalers) {
+		return nil, fmt.Errorf("scaler with id %d not found, len = %d, cache has been probably invalidated", index, len(c.scalers))
+	}
+
+	current := c.scalers[index]
+	if current != failed {
+		current.acquire()
+		return current, nil
+	}
+	if !current.rebuiltAt.IsZero() && time.Since(current.rebuiltAt) < minScalerRebuildInterval {
+		return nil, fmt.Errorf("scaler with id %d was rebuilt less than %s ago", index, minScalerRebuildInterval)
+	}
+
+	ns, err := current.factory()
+	if err != nil {
+		c.Logger.Error(err, "error rebuilding failing scaler", "scaler", current.scaler)
+		return nil, err
+	}
+
+	replacement := &cachedScaler{scaler: ns, factory: current.factory, rebuiltAt: time.Now()}
+	replacement.acquire()
+	c.scalers[index] = replacement
+	current.retire(c.Logger)
+	c.Logger.V(1).Info("Scaler was rebuilt because of a previous failure", "index", index)
+
+	return replacement, nil
+}
+
+func (s *cachedScaler) acquire() {
+	s.refLock.Lock()
+	defer s.refLock.Unlock()
+
+	s.refs++
+}
+
+// release is called once a call on the scaler finishes, a retired scaler is closed by its last caller
+func (s *cachedScaler) release(logger logr.Logger) {
+	s.refLock.Lock()
+	defer s.refLock.Unlock()
+
+	s.refs--
+	if s.retired && s.refs == 0 {
+		s.close(logger)
+	}
+}
+
+// retire marks the scaler as no longer used by the cache, it is closed as soon as no call is in flight
+func (s *cachedScaler) retire(logger logr.Logger) {
+	s.refLock.Lock()
+	defer s.refLock.Unlock()
+
+	if s.retired {
+		return
+	}
+	s.retired = true
+	if s.refs == 0 {
+		s.close(logger)
+	}
+}
+
+func (s *cachedScaler) close(logger logr.Logger) {
+	if err := s.scaler.Close(); err != nil {
+		logger.Error(err, "error closing scaler", "scaler", s.scaler)
+	}
+}
@@ -0,0 +1,194 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kedacore/keda/pkg/scalers"
+)
+
+type fakeScaler struct {
+	failing bool
+	closed  bool
+}
+
+func (s *fakeScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	if s.failing {
+		return nil, errors.New("scaler is failing")
+	}
+	return []external_metrics.ExternalMetricValue{{MetricName: metricName}}, nil
+}
+
+func (s *fakeScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
+	return nil
+}
+
+func (s *fakeScaler) IsActive(ctx context.Context) (bool, error) {
+	if s.failing {
+		return false, errors.New("scaler is failing")
+	}
+	return true, nil
+}
+
+func (s *fakeScaler) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newTestCache(initial *fakeScaler, built *int) *ScalersCache {
+	factory := func() (scalers.Scaler, error) {
+		*built++
+		return &fakeScaler{}, nil
+	}
+	return NewScalersCache(1, 0, logf.Log.WithName("test"), []ScalerBuilder{{Scaler: initial, Factory: factory}})
+}
+
+func TestIsScalerActiveReusesHealthyScaler(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{}
+	c := newTestCache(initial, &built)
+
+	for i := 0; i < 3; i++ {
+		isActive, err := c.IsScalerActive(context.TODO(), 0)
+		assert.NoError(t, err)
+		assert.True(t, isActive)
+	}
+
+	assert.Equal(t, 0, built)
+	assert.False(t, initial.closed)
+	assert.Same(t, initial, c.GetScalers()[0])
+}
+
+func TestIsScalerActiveRebuildsFailingScaler(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{failing: true}
+	c := newTestCache(initial, &built)
+
+	isActive, err := c.IsScalerActive(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.True(t, isActive)
+
+	assert.Equal(t, 1, built)
+	assert.True(t, initial.closed)
+	assert.NotSame(t, initial, c.GetScalers()[0])
+}
+
+func TestGetMetricsForScalerRebuildsFailingScaler(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{failing: true}
+	c := newTestCache(initial, &built)
+
+	metrics, err := c.GetMetricsForScaler(context.TODO(), 0, "metric", nil)
+	assert.NoError(t, err)
+	assert.Len(t, metrics, 1)
+	assert.Equal(t, 1, built)
+}
+
+func TestCloseClosesScalers(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{}
+	c := newTestCache(initial, &built)
+
+	c.Close()
+	assert.True(t, initial.closed)
+	assert.Empty(t, c.GetScalers())
+
+	_, err := c.IsScalerActive(context.TODO(), 0)
+	assert.Error(t, err)
+}
+
+func TestCloseWaitsForRelease(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{}
+	c := newTestCache(initial, &built)
+
+	c.Acquire()
+	c.Acquire()
+	c.Close()
+	assert.False(t, initial.closed)
+
+	c.Release()
+	assert.False(t, initial.closed)
+
+	c.Release()
+	assert.True(t, initial.closed)
+}
+
+func TestCloseUnheldCache(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{}
+	c := newTestCache(initial, &built)
+
+	c.Acquire()
+	c.Release()
+	assert.False(t, initial.closed)
+
+	c.Close()
+	assert.True(t, initial.closed)
+}
+
+func TestRefreshScalerReusesConcurrentRebuild(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{failing: true}
+	c := newTestCache(initial, &built)
+	failed, err := c.acquireScaler(0)
+	assert.NoError(t, err)
+	failed.release(c.Logger)
+
+	// both callers saw the same scaler failing, only the first one rebuilds it
+	first, err := c.refreshScaler(0, failed)
+	assert.NoError(t, err)
+	second, err := c.refreshScaler(0, failed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, built)
+	assert.Same(t, first, second)
+	assert.False(t, first.scaler.(*fakeScaler).closed)
+	first.release(c.Logger)
+	second.release(c.Logger)
+}
+
+func TestRefreshScalerWaitsForCallsInFlight(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{failing: true}
+	c := newTestCache(initial, &built)
+
+	inFlight, err := c.acquireScaler(0)
+	assert.NoError(t, err)
+
+	replacement, err := c.refreshScaler(0, inFlight)
+	assert.NoError(t, err)
+	replacement.release(c.Logger)
+	assert.False(t, initial.closed)
+
+	inFlight.release(c.Logger)
+	assert.True(t, initial.closed)
+}
+
+func TestRefreshScalerIsRateLimited(t *testing.T) {
+	built := 0
+	initial := &fakeScaler{failing: true}
+	c := newTestCache(initial, &built)
+
+	_, err := c.IsScalerActive(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, built)
+
+	c.GetScalers()[0].(*fakeScaler).failing = true
+	_, err = c.IsScalerActive(context.TODO(), 0)
+	assert.Error(t, err)
+	assert.Equal(t, 1, built, "the scaler was rebuilt recently")
+
+	c.scalers[0].rebuiltAt = time.Now().Add(-minScalerRebuildInterval)
+	_, err = c.IsScalerActive(context.TODO(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, built)
+}
@@ -0,0 +1,31 @@
+package executor
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	kedautil "github.com/kedacore/keda/pkg/util"
+)
+
+// RequestDuckScale records the activity of a custom resource with triggers in its status,
+// the custom resource's own controller is responsible for scaling based on status.lastActiveTime and the Active condition
+func (e *scaleExecutor) RequestDuckScale(ctx context.Context, object *unstructured.Unstructured, isActive bool) {
+	logger := e.logger.WithValues("kind", object.GetKind(), "namespace", object.GetNamespace(), "name", object.GetName())
+
+	if isActive {
+		// Update LastActiveTime to now.
+		e.updateLastActiveTime(ctx, logger, object)
+	}
+
+	condition := kedautil.GetUnstructuredCondition(object, kedav1alpha1.ConditionActive)
+	if condition.IsUnknown() || condition.IsTrue() != isActive {
+		if isActive {
+			e.setActiveCondition(ctx, logger, object, metav1.ConditionTrue, "ScalerActive", "Scaling is performed because triggers are active")
+		} else {
+			e.setActiveCondition(ctx, logger, object, metav1.ConditionFalse, "ScalerNotActive", "Scaling is not performed because triggers are not active")
+		}
+	}
+}
@@ -6,12 +6,14 @@ import (
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/scale"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	kedautil "github.com/kedacore/keda/pkg/util"
 )
 
 const (
@@ -19,10 +21,11 @@ const (
 	defaultCooldownPeriod = 5 * 60 // 5 minutes
 )
 
-// ScaleExecutor contains methods RequestJobScale and RequestScale
+// ScaleExecutor contains methods RequestJobScale, RequestScale and RequestDuckScale
 type ScaleExecutor interface {
 	RequestJobScale(ctx context.Context, scaledJob *kedav1alpha1.ScaledJob, isActive bool, scaleTo int64, maxScale int64)
-	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool)
+	RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool)
+	RequestDuckScale(ctx context.Context, object *unstructured.Unstructured, isActive bool)
 }
 
 type scaleExecutor struct {
@@ -54,6 +57,12 @@ func (e *scaleExecutor) updateLastActiveTime(ctx context.Context, logger logr.Lo
 	case *kedav1alpha1.ScaledJob:
 		patch = client.MergeFrom(obj.DeepCopy())
 		obj.Status.LastActiveTime = &now
+	case *unstructured.Unstructured:
+		patch = client.MergeFrom(obj.DeepCopy())
+		if err := kedautil.SetUnstructuredLastActiveTime(obj, now); err != nil {
+			logger.Error(err, "Failed to patch Objects Status")
+			return err
+		}
 	default:
 		err := fmt.Errorf("unknown scalable object type %v", obj)
 		logger.Error(err, "Failed to patch Objects Status")
@@ -78,6 +87,12 @@ func (e *scaleExecutor) setActiveCondition(ctx context.Context, logger logr.Logg
 	case *kedav1alpha1.ScaledJob:
 		patch = client.MergeFrom(obj.DeepCopy())
 		obj.Status.Conditions.SetActiveCondition(status, reason, mesage)
+	case *unstructured.Unstructured:
+		patch = client.MergeFrom(obj.DeepCopy())
+		if err := kedautil.SetUnstructuredCondition(obj, kedav1alpha1.ConditionActive, status, reason, mesage); err != nil {
+			logger.Error(err, "Failed to patch Objects Status")
+			return err
+		}
 	default:
 		err := fmt.Errorf("unknown scalable object type %v", obj)
 		logger.Error(err, "Failed to patch Objects Status")
@@ -90,3 +105,14 @@ func (e *scaleExecutor) setActiveCondition(ctx context.Context, logger logr.Logg
 	}
 	return err
 }
+
+func (e *scaleExecutor) setFallbackCondition(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, status metav1.ConditionStatus, reason string, message string) error {
+	patch := client.MergeFrom(scaledObject.DeepCopy())
+	scaledObject.Status.Conditions.SetFallbackCondition(status, reason, message)
+
+	err := e.client.Status().Patch(ctx, scaledObject, patch)
+	if err != nil {
+		logger.Error(err, "Failed to patch ScaledObjects Status")
+	}
+	return err
+}
@@ -9,9 +9,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/fallback"
 )
 
-func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool) {
+func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, isActive bool, isError bool) {
 	logger := e.logger.WithValues("scaledobject.Name", scaledObject.Name,
 		"scaledObject.Namespace", scaledObject.Namespace,
 		"scaleTarget.Name", scaledObject.Spec.ScaleTargetRef.Name)
@@ -22,7 +23,13 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		return
 	}
 
-	if currentScale.Spec.Replicas == 0 && isActive {
+	isFallbackActive := !isActive && isError && fallback.HasFailingMetrics(scaledObject)
+
+	if isFallbackActive {
+		// there are no active triggers, but some triggers keep failing
+		// keep the ScaleTarget at the fallback replicas count instead of scaling it to zero
+		e.doFallbackScaling(ctx, logger, scaledObject, currentScale)
+	} else if currentScale.Spec.Replicas == 0 && isActive {
 		// current replica count is 0, but there is an active trigger.
 		// scale the ScaleTarget up
 		e.scaleFromZero(ctx, logger, scaledObject, currentScale)
@@ -57,6 +64,11 @@ func (e *scaleExecutor) RequestScale(ctx context.Context, scaledObject *kedav1al
 		logger.V(1).Info("ScaleTarget no change")
 	}
 
+	fallbackCondition := scaledObject.Status.Conditions.GetFallbackCondition()
+	if !isFallbackActive && fallbackCondition.IsTrue() {
+		e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionFalse, "NoFallbackFound", "No fallback was found")
+	}
+
 	condition := scaledObject.Status.Conditions.GetActiveCondition()
 	if condition.IsUnknown() || condition.IsTrue() != isActive {
 		if isActive {
@@ -101,6 +113,27 @@ func (e *scaleExecutor) scaleToZero(ctx context.Context, logger logr.Logger, sca
 	}
 }
 
+func (e *scaleExecutor) doFallbackScaling(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
+	fallbackReplicas := scaledObject.Spec.Fallback.Replicas
+	if scale.Spec.Replicas != fallbackReplicas {
+		currentReplicas := scale.Spec.Replicas
+		scale.Spec.Replicas = fallbackReplicas
+		err := e.updateScaleOnScaleTarget(ctx, scaledObject, scale)
+		if err != nil {
+			logger.Error(err, "Error scaling ScaleTarget to fallback replicas count")
+			return
+		}
+		logger.Info("Successfully set ScaleTarget replicas count to fallback replicas count",
+			"Original Replicas Count", currentReplicas,
+			"New Replicas Count", scale.Spec.Replicas)
+	}
+
+	fallbackCondition := scaledObject.Status.Conditions.GetFallbackCondition()
+	if !fallbackCondition.IsTrue() {
+		e.setFallbackCondition(ctx, logger, scaledObject, metav1.ConditionTrue, "FallbackExists", "At least one trigger is falling back on this scaled object")
+	}
+}
+
 func (e *scaleExecutor) scaleFromZero(ctx context.Context, logger logr.Logger, scaledObject *kedav1alpha1.ScaledObject, scale *autoscalingv1.Scale) {
 	currentReplicas := scale.Spec.Replicas
 	if scaledObject.Spec.MinReplicaCount != nil && *scaledObject.Spec.MinReplicaCount > 0 {
@@ -0,0 +1,184 @@
+package modifiers
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"strconv"
+)
+
+// Formula is a compiled arithmetic expression over named values, eg. `(kafka_lag + sqs_depth) / 2`
+// Supported are numeric literals, identifiers, parentheses, unary `-` and `+`,
+// binary `+`, `-`, `*`, `/` and functions `min`, `max`, `abs`, `ceil` and `floor`
+type Formula struct {
+	expression  string
+	root        ast.Expr
+	identifiers []string
+}
+
+// Compile parses the formula and checks that only supported constructs are used
+func Compile(expression string) (*Formula, error) {
+	root, err := parser.ParseExpr(expression)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing formula %q: %s", expression, err)
+	}
+
+	f := &Formula{
+		expression: expression,
+		root:       root,
+	}
+	seen := map[string]bool{}
+	if err := f.check(root, seen); err != nil {
+		return nil, fmt.Errorf("invalid formula %q: %s", expression, err)
+	}
+
+	return f, nil
+}
+
+// Identifiers returns names of all values referenced in the formula
+func (f *Formula) Identifiers() []string {
+	return f.identifiers
+}
+
+// Evaluate computes the formula, values for all identifiers must be present
+func (f *Formula) Evaluate(values map[string]float64) (float64, error) {
+	result, err := f.evaluate(f.root, values)
+	if err != nil {
+		return 0, fmt.Errorf("error evaluating formula %q: %s", f.expression, err)
+	}
+	return result, nil
+}
+
+func (f *Formula) check(node ast.Expr, seen map[string]bool) error {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		if n.Kind != token.INT && n.Kind != token.FLOAT {
+			return fmt.Errorf("unsupported literal %s", n.Value)
+		}
+	case *ast.Ident:
+		if !seen[n.Name] {
+			seen[n.Name] = true
+			f.identifiers = append(f.identifiers, n.Name)
+		}
+	case *ast.ParenExpr:
+		return f.check(n.X, seen)
+	case *ast.UnaryExpr:
+		if n.Op != token.SUB && n.Op != token.ADD {
+			return fmt.Errorf("unsupported operator %s", n.Op)
+		}
+		return f.check(n.X, seen)
+	case *ast.BinaryExpr:
+		switch n.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO:
+		default:
+			return fmt.Errorf("unsupported operator %s", n.Op)
+		}
+		if err := f.check(n.X, seen); err != nil {
+			return err
+		}
+		return f.check(n.Y, seen)
+	case *ast.CallExpr:
+		fn, ok := n.Fun.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("unsupported function call")
+		}
+		switch fn.Name {
+		case "min", "max":
+			if len(n.Args) < 1 {
+				return fmt.Errorf("function %s requires at least one argument", fn.Name)
+			}
+		case "abs", "ceil", "floor":
+			if len(n.Args) != 1 {
+				return fmt.Errorf("function %s requires exactly one argument", fn.Name)
+			}
+		default:
+			return fmt.Errorf("unsupported function %s", fn.Name)
+		}
+		for _, arg := range n.Args {
+			if err := f.check(arg, seen); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported expression %T", node)
+	}
+	return nil
+}
+
+func (f *Formula) evaluate(node ast.Expr, values map[string]float64) (float64, error) {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		return strconv.ParseFloat(n.Value, 64)
+	case *ast.Ident:
+		value, ok := values[n.Name]
+		if !ok {
+			return 0, fmt.Errorf("no value for %s", n.Name)
+		}
+		return value, nil
+	case *ast.ParenExpr:
+		return f.evaluate(n.X, values)
+	case *ast.UnaryExpr:
+		x, err := f.evaluate(n.X, values)
+		if err != nil {
+			return 0, err
+		}
+		if n.Op == token.SUB {
+			return -x, nil
+		}
+		return x, nil
+	case *ast.BinaryExpr:
+		x, err := f.evaluate(n.X, values)
+		if err != nil {
+			return 0, err
+		}
+		y, err := f.evaluate(n.Y, values)
+		if err != nil {
+			return 0, err
+		}
+		switch n.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		default:
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		}
+	case *ast.CallExpr:
+		args := make([]float64, len(n.Args))
+		for i, arg := range n.Args {
+			value, err := f.evaluate(arg, values)
+			if err != nil {
+				return 0, err
+			}
+			args[i] = value
+		}
+		switch n.Fun.(*ast.Ident).Name {
+		case "min":
+			result := args[0]
+			for _, a := range args[1:] {
+				result = math.Min(result, a)
+			}
+			return result, nil
+		case "max":
+			result := args[0]
+			for _, a := range args[1:] {
+				result = math.Max(result, a)
+			}
+			return result, nil
+		case "abs":
+			return math.Abs(args[0]), nil
+		case "ceil":
+			return math.Ceil(args[0]), nil
+		default:
+			return math.Floor(args[0]), nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported expression %T", node)
+}
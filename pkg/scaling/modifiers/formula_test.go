@@ -0,0 +1,68 @@
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type formulaTestData struct {
+	formula string
+	values  map[string]float64
+	result  float64
+	isError bool
+}
+
+var testFormulas = []formulaTestData{
+	{"kafka + sqs", map[string]float64{"kafka": 10, "sqs": 5}, 15, false},
+	{"(kafka + sqs) / 2", map[string]float64{"kafka": 10, "sqs": 5}, 7.5, false},
+	{"-kafka * 2 + 30", map[string]float64{"kafka": 10}, 10, false},
+	{"max(kafka, sqs, 12)", map[string]float64{"kafka": 10, "sqs": 5}, 12, false},
+	{"min(kafka, sqs)", map[string]float64{"kafka": 10, "sqs": 5}, 5, false},
+	{"ceil(kafka / 3) + floor(sqs / 2) + abs(-1)", map[string]float64{"kafka": 10, "sqs": 5}, 7, false},
+	// division by zero
+	{"kafka / sqs", map[string]float64{"kafka": 10, "sqs": 0}, 0, true},
+	// missing value
+	{"kafka + sqs", map[string]float64{"kafka": 10}, 0, true},
+}
+
+var testInvalidFormulas = []string{
+	"",
+	"kafka +",
+	"kafka % 2",
+	"kafka == 2",
+	"\"kafka\"",
+	"pow(kafka, 2)",
+	"abs(kafka, sqs)",
+	"min()",
+	"kafka.lag",
+	"kafka[0]",
+}
+
+func TestEvaluateFormula(t *testing.T) {
+	for _, testData := range testFormulas {
+		formula, err := Compile(testData.formula)
+		assert.NoError(t, err, testData.formula)
+
+		result, err := formula.Evaluate(testData.values)
+		if testData.isError {
+			assert.Error(t, err, testData.formula)
+			continue
+		}
+		assert.NoError(t, err, testData.formula)
+		assert.InDelta(t, testData.result, result, 0.0001, testData.formula)
+	}
+}
+
+func TestCompileInvalidFormula(t *testing.T) {
+	for _, formula := range testInvalidFormulas {
+		_, err := Compile(formula)
+		assert.Error(t, err, formula)
+	}
+}
+
+func TestFormulaIdentifiers(t *testing.T) {
+	formula, err := Compile("max(kafka, sqs) + kafka / redis")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"kafka", "sqs", "redis"}, formula.Identifiers())
+}
@@ -0,0 +1,140 @@
+package modifiers
+
+import (
+	"fmt"
+	"strconv"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+const (
+	// CompositeMetricName is the name of the external metric computed from the scalingModifiers formula
+	CompositeMetricName = "composite-metric"
+	// CompositeScalerName is the scaler name the composite metric is reported with
+	CompositeScalerName = "scalingModifiers"
+)
+
+// GetCompositeScalerIndex returns the scaler index the composite metric is reported with,
+// it follows the indexes of the ScaledObject's triggers
+func GetCompositeScalerIndex(scaledObject *kedav1alpha1.ScaledObject) int {
+	return len(scaledObject.Spec.Triggers)
+}
+
+// GetScalingModifiers returns scalingModifiers of the ScaledObject or nil if they are not defined
+func GetScalingModifiers(scaledObject *kedav1alpha1.ScaledObject) *kedav1alpha1.ScalingModifiers {
+	if scaledObject.Spec.Advanced == nil || scaledObject.Spec.Advanced.ScalingModifiers == nil ||
+		scaledObject.Spec.Advanced.ScalingModifiers.Formula == "" {
+		return nil
+	}
+	return scaledObject.Spec.Advanced.ScalingModifiers
+}
+
+// GetMetricType returns the HPA target type of the composite metric, AverageValue is used by default
+func GetMetricType(scalingModifiers *kedav1alpha1.ScalingModifiers) autoscalingv2beta2.MetricTargetType {
+	if scalingModifiers.MetricType == "" {
+		return autoscalingv2beta2.AverageValueMetricType
+	}
+	return scalingModifiers.MetricType
+}
+
+// GetTargets returns parsed target and activation target of the composite metric
+func GetTargets(scalingModifiers *kedav1alpha1.ScalingModifiers) (float64, float64, error) {
+	target, err := strconv.ParseFloat(scalingModifiers.Target, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error parsing scalingModifiers.target: %s", err)
+	}
+	if target <= 0 {
+		return 0, 0, fmt.Errorf("scalingModifiers.target must be greater than 0")
+	}
+
+	activationTarget := 0.0
+	if scalingModifiers.ActivationTarget != "" {
+		activationTarget, err = strconv.ParseFloat(scalingModifiers.ActivationTarget, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("error parsing scalingModifiers.activationTarget: %s", err)
+		}
+	}
+
+	return target, activationTarget, nil
+}
+
+// GetCompositeMetricSpec returns External MetricSpec for the metric computed from scalingModifiers formula
+func GetCompositeMetricSpec(scaledObject *kedav1alpha1.ScaledObject) (autoscalingv2beta2.MetricSpec, error) {
+	scalingModifiers := GetScalingModifiers(scaledObject)
+	if scalingModifiers == nil {
+		return autoscalingv2beta2.MetricSpec{}, fmt.Errorf("scalingModifiers are not specified")
+	}
+
+	target, _, err := GetTargets(scalingModifiers)
+	if err != nil {
+		return autoscalingv2beta2.MetricSpec{}, err
+	}
+	targetQuantity := resource.NewMilliQuantity(int64(target*1000), resource.DecimalSI)
+
+	metricTarget := autoscalingv2beta2.MetricTarget{
+		Type: GetMetricType(scalingModifiers),
+	}
+	if metricTarget.Type == autoscalingv2beta2.ValueMetricType {
+		metricTarget.Value = targetQuantity
+	} else {
+		metricTarget.AverageValue = targetQuantity
+	}
+
+	return autoscalingv2beta2.MetricSpec{
+		Type: autoscalingv2beta2.ExternalMetricSourceType,
+		External: &autoscalingv2beta2.ExternalMetricSource{
+			Metric: autoscalingv2beta2.MetricIdentifier{
+				Name: CompositeMetricName,
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"scaledObjectName": scaledObject.Name},
+				},
+			},
+			Target: metricTarget,
+		},
+	}, nil
+}
+
+// Validate checks scalingModifiers of the ScaledObject, the formula has to be valid
+// and reference only names of the ScaledObject's triggers
+func Validate(scaledObject *kedav1alpha1.ScaledObject) error {
+	scalingModifiers := GetScalingModifiers(scaledObject)
+	if scalingModifiers == nil {
+		return nil
+	}
+
+	if _, _, err := GetTargets(scalingModifiers); err != nil {
+		return err
+	}
+
+	metricType := GetMetricType(scalingModifiers)
+	if metricType != autoscalingv2beta2.AverageValueMetricType && metricType != autoscalingv2beta2.ValueMetricType {
+		return fmt.Errorf("unsupported scalingModifiers.metricType %s, only AverageValue and Value are supported", metricType)
+	}
+
+	formula, err := Compile(scalingModifiers.Formula)
+	if err != nil {
+		return err
+	}
+
+	triggerNames := map[string]bool{}
+	for _, trigger := range scaledObject.Spec.Triggers {
+		if trigger.Name == "" {
+			continue
+		}
+		if triggerNames[trigger.Name] {
+			return fmt.Errorf("trigger name %s is used more than once", trigger.Name)
+		}
+		triggerNames[trigger.Name] = true
+	}
+	for _, name := range formula.Identifiers() {
+		if !triggerNames[name] {
+			return fmt.Errorf("formula references %s, but there is no trigger with such name", name)
+		}
+	}
+
+	return nil
+}
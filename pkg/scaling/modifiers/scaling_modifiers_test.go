@@ -0,0 +1,70 @@
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+type validateTestData struct {
+	scalingModifiers *kedav1alpha1.ScalingModifiers
+	isError          bool
+}
+
+var testValidate = []validateTestData{
+	// no scalingModifiers
+	{nil, false},
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka + sqs", Target: "10"}, false},
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka + sqs", Target: "2.5", ActivationTarget: "0.5", MetricType: autoscalingv2beta2.ValueMetricType}, false},
+	// unknown trigger
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka + redis", Target: "10"}, true},
+	// invalid formula
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka +", Target: "10"}, true},
+	// missing target
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka + sqs"}, true},
+	// target is not positive
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka + sqs", Target: "0"}, true},
+	// invalid activationTarget
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka + sqs", Target: "10", ActivationTarget: "a"}, true},
+	// unsupported metricType
+	{&kedav1alpha1.ScalingModifiers{Formula: "kafka + sqs", Target: "10", MetricType: autoscalingv2beta2.UtilizationMetricType}, true},
+}
+
+func TestValidate(t *testing.T) {
+	for _, testData := range testValidate {
+		scaledObject := &kedav1alpha1.ScaledObject{
+			Spec: kedav1alpha1.ScaledObjectSpec{
+				Advanced: &kedav1alpha1.AdvancedConfig{ScalingModifiers: testData.scalingModifiers},
+				Triggers: []kedav1alpha1.ScaleTriggers{
+					{Type: "kafka", Name: "kafka"},
+					{Type: "aws-sqs-queue", Name: "sqs"},
+					{Type: "cpu"},
+				},
+			},
+		}
+
+		err := Validate(scaledObject)
+		if testData.isError {
+			assert.Error(t, err, testData.scalingModifiers)
+		} else {
+			assert.NoError(t, err, testData.scalingModifiers)
+		}
+	}
+}
+
+func TestValidateDuplicateTriggerNames(t *testing.T) {
+	scaledObject := &kedav1alpha1.ScaledObject{
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			Advanced: &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "kafka", Target: "10"}},
+			Triggers: []kedav1alpha1.ScaleTriggers{
+				{Type: "kafka", Name: "kafka"},
+				{Type: "kafka", Name: "kafka"},
+			},
+		},
+	}
+
+	assert.Error(t, Validate(scaledObject))
+}
@@ -0,0 +1,20 @@
+package resolver
+
+import (
+	"os"
+)
+
+const (
+	clusterObjectNamespaceEnv     = "KEDA_CLUSTER_OBJECT_NAMESPACE"
+	defaultClusterObjectNamespace = "keda"
+)
+
+// GetClusterObjectNamespace returns the namespace that secrets referenced by cluster scoped objects
+// (eg. ClusterTriggerAuthentication) are read from. It is configured by KEDA_CLUSTER_OBJECT_NAMESPACE env variable,
+// the namespace of KEDA is used by default.
+func GetClusterObjectNamespace() string {
+	if namespace, found := os.LookupEnv(clusterObjectNamespaceEnv); found && namespace != "" {
+		return namespace
+	}
+	return defaultClusterObjectNamespace
+}
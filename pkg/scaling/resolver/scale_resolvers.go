@@ -7,6 +7,8 @@ import (
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -54,16 +56,15 @@ func ResolveAuthRef(client client.Client, logger logr.Logger, triggerAuthRef *ke
 	var podIdentity kedav1alpha1.PodIdentityProvider
 
 	if namespace != "" && triggerAuthRef != nil && triggerAuthRef.Name != "" {
-		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
-		err := client.Get(context.TODO(), types.NamespacedName{Name: triggerAuthRef.Name, Namespace: namespace}, triggerAuth)
+		triggerAuthSpec, triggerAuthNamespace, err := getTriggerAuthSpec(client, triggerAuthRef, namespace)
 		if err != nil {
-			logger.Error(err, "Error getting triggerAuth", "triggerAuthRef.Name", triggerAuthRef.Name)
+			logger.Error(err, "Error getting triggerAuth", "triggerAuthRef.Name", triggerAuthRef.Name, "triggerAuthRef.Kind", triggerAuthRef.Kind)
 		} else {
-			if triggerAuth.Spec.PodIdentity != nil {
-				podIdentity = triggerAuth.Spec.PodIdentity.Provider
+			if triggerAuthSpec.PodIdentity != nil {
+				podIdentity = triggerAuthSpec.PodIdentity.Provider
 			}
-			if triggerAuth.Spec.Env != nil {
-				for _, e := range triggerAuth.Spec.Env {
+			if triggerAuthSpec.Env != nil {
+				for _, e := range triggerAuthSpec.Env {
 					if podSpec == nil {
 						result[e.Parameter] = ""
 						continue
@@ -76,18 +77,18 @@ func ResolveAuthRef(client client.Client, logger logr.Logger, triggerAuthRef *ke
 					}
 				}
 			}
-			if triggerAuth.Spec.SecretTargetRef != nil {
-				for _, e := range triggerAuth.Spec.SecretTargetRef {
-					result[e.Parameter] = resolveAuthSecret(client, logger, e.Name, namespace, e.Key)
+			if triggerAuthSpec.SecretTargetRef != nil {
+				for _, e := range triggerAuthSpec.SecretTargetRef {
+					result[e.Parameter] = resolveAuthSecret(client, logger, e.Name, triggerAuthNamespace, e.Key)
 				}
 			}
-			if triggerAuth.Spec.HashiCorpVault != nil && len(triggerAuth.Spec.HashiCorpVault.Secrets) > 0 {
-				vault := NewHashicorpVaultHandler(triggerAuth.Spec.HashiCorpVault)
+			if triggerAuthSpec.HashiCorpVault != nil && len(triggerAuthSpec.HashiCorpVault.Secrets) > 0 {
+				vault := NewHashicorpVaultHandler(triggerAuthSpec.HashiCorpVault)
 				err := vault.Initialize(logger)
 				if err != nil {
 					logger.Error(err, "Error authenticate to Vault", "triggerAuthRef.Name", triggerAuthRef.Name)
 				} else {
-					for _, e := range triggerAuth.Spec.HashiCorpVault.Secrets {
+					for _, e := range triggerAuthSpec.HashiCorpVault.Secrets {
 						secret, err := vault.Read(e.Path)
 						if err != nil {
 							logger.Error(err, "Error trying to read secret from Vault", "triggerAuthRef.Name", triggerAuthRef.Name,
@@ -107,6 +108,63 @@ func ResolveAuthRef(client client.Client, logger logr.Logger, triggerAuthRef *ke
 	return result, podIdentity
 }
 
+// getTriggerAuthSpec returns the spec of the TriggerAuthentication or ClusterTriggerAuthentication referenced by triggerAuthRef
+// and the namespace the secrets referenced by the spec are read from
+func getTriggerAuthSpec(client client.Client, triggerAuthRef *kedav1alpha1.ScaledObjectAuthRef, namespace string) (*kedav1alpha1.TriggerAuthenticationSpec, string, error) {
+	switch triggerAuthRef.Kind {
+	case "", "TriggerAuthentication":
+		triggerAuth := &kedav1alpha1.TriggerAuthentication{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: triggerAuthRef.Name, Namespace: namespace}, triggerAuth)
+		if err != nil {
+			return nil, "", err
+		}
+		return &triggerAuth.Spec, namespace, nil
+	case "ClusterTriggerAuthentication":
+		clusterTriggerAuth := &kedav1alpha1.ClusterTriggerAuthentication{}
+		err := client.Get(context.TODO(), types.NamespacedName{Name: triggerAuthRef.Name}, clusterTriggerAuth)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := checkClusterTriggerAuthNamespace(client, clusterTriggerAuth, namespace); err != nil {
+			return nil, "", err
+		}
+		return &clusterTriggerAuth.Spec.TriggerAuthenticationSpec, GetClusterObjectNamespace(), nil
+	default:
+		return nil, "", fmt.Errorf("unknown triggerAuthRef.kind: %s", triggerAuthRef.Kind)
+	}
+}
+
+// checkClusterTriggerAuthNamespace returns an error if the namespace isn't allowed to use the ClusterTriggerAuthentication,
+// every namespace is allowed if neither allowedNamespaces nor namespaceSelector is specified
+func checkClusterTriggerAuthNamespace(client client.Client, clusterTriggerAuth *kedav1alpha1.ClusterTriggerAuthentication, namespace string) error {
+	spec := clusterTriggerAuth.Spec
+	if len(spec.AllowedNamespaces) == 0 && spec.NamespaceSelector == nil {
+		return nil
+	}
+
+	for _, allowedNamespace := range spec.AllowedNamespaces {
+		if allowedNamespace == namespace {
+			return nil
+		}
+	}
+
+	if spec.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("error parsing namespaceSelector of ClusterTriggerAuthentication %s: %s", clusterTriggerAuth.Name, err)
+		}
+		ns := &corev1.Namespace{}
+		if err := client.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+			return fmt.Errorf("error getting namespace %s: %s", namespace, err)
+		}
+		if selector.Matches(labels.Set(ns.Labels)) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("namespace %s is not allowed to use ClusterTriggerAuthentication %s", namespace, clusterTriggerAuth.Name)
+}
+
 func resolveEnv(client client.Client, logger logr.Logger, container *corev1.Container, namespace string) (map[string]string, error) {
 	resolved := make(map[string]string)
 
@@ -14,25 +14,26 @@ import (
 )
 
 var (
-	namespace                 = "test-namespace"
-	triggerAuthenticationName = "triggerauth"
-	secretName                = "supersecret"
-	secretKey                 = "mysecretkey"
-	secretData                = "secretDataHere"
-	trueValue                 = true
-	falseValue                = false
-	envKey                    = "test-env-key"
-	envValue                  = "test-env-value"
-	dependentEnvKey           = "dependent-env-key"
-	dependentEnvValue         = "$(test-env-key)-dependent-env-value"
-	dependentEnvKey2          = "dependent-env-key2"
-	dependentEnvValue2        = "dependent-env-value2-$(test-env-key)"
-	escapedEnvKey             = "escaped-env-key"
-	escapedEnvValue           = "$$(test-env-key)-escaped-env-value"
-	emptyEnvKey               = "empty-env-key"
-	emptyEnvValue             = "$()-empty-env-value"
-	incompleteEnvKey          = "incomplete-env-key"
-	incompleteValue           = "$(test-env-key-incomplete-env-value"
+	namespace                        = "test-namespace"
+	triggerAuthenticationName        = "triggerauth"
+	clusterTriggerAuthenticationName = "clustertriggerauth"
+	secretName                       = "supersecret"
+	secretKey                        = "mysecretkey"
+	secretData                       = "secretDataHere"
+	trueValue                        = true
+	falseValue                       = false
+	envKey                           = "test-env-key"
+	envValue                         = "test-env-value"
+	dependentEnvKey                  = "dependent-env-key"
+	dependentEnvValue                = "$(test-env-key)-dependent-env-value"
+	dependentEnvKey2                 = "dependent-env-key2"
+	dependentEnvValue2               = "dependent-env-value2-$(test-env-key)"
+	escapedEnvKey                    = "escaped-env-key"
+	escapedEnvValue                  = "$$(test-env-key)-escaped-env-value"
+	emptyEnvKey                      = "empty-env-key"
+	emptyEnvValue                    = "$()-empty-env-value"
+	incompleteEnvKey                 = "incomplete-env-key"
+	incompleteValue                  = "$(test-env-key-incomplete-env-value"
 )
 
 type testMetadata struct {
@@ -219,6 +220,53 @@ func TestResolveAuthRef(t *testing.T) {
 			expected:            map[string]string{"host": secretData},
 			expectedPodIdentity: kedav1alpha1.PodIdentityProviderNone,
 		},
+		{
+			name: "clustertriggerauth exists and secret in cluster object namespace",
+			existing: []runtime.Object{
+				newClusterTriggerAuthentication(nil, nil),
+				newClusterObjectNamespaceSecret(),
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: clusterTriggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expected: map[string]string{"host": secretData},
+		},
+		{
+			name: "clustertriggerauth exists, namespace not allowed",
+			existing: []runtime.Object{
+				newClusterTriggerAuthentication([]string{"other-namespace"}, nil),
+				newClusterObjectNamespaceSecret(),
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: clusterTriggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expected: make(map[string]string),
+		},
+		{
+			name: "clustertriggerauth exists, namespace allowed",
+			existing: []runtime.Object{
+				newClusterTriggerAuthentication([]string{"other-namespace", namespace}, nil),
+				newClusterObjectNamespaceSecret(),
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: clusterTriggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expected: map[string]string{"host": secretData},
+		},
+		{
+			name: "clustertriggerauth exists, namespace matches selector",
+			existing: []runtime.Object{
+				newClusterTriggerAuthentication(nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}),
+				newClusterObjectNamespaceSecret(),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: map[string]string{"team": "platform"}}},
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: clusterTriggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expected: map[string]string{"host": secretData},
+		},
+		{
+			name: "clustertriggerauth exists, namespace doesn't match selector",
+			existing: []runtime.Object{
+				newClusterTriggerAuthentication(nil, &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}}),
+				newClusterObjectNamespaceSecret(),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace, Labels: map[string]string{"team": "other"}}},
+			},
+			soar:     &kedav1alpha1.ScaledObjectAuthRef{Name: clusterTriggerAuthenticationName, Kind: "ClusterTriggerAuthentication"},
+			expected: make(map[string]string),
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -234,6 +282,37 @@ func TestResolveAuthRef(t *testing.T) {
 	}
 }
 
+func newClusterTriggerAuthentication(allowedNamespaces []string, namespaceSelector *metav1.LabelSelector) *kedav1alpha1.ClusterTriggerAuthentication {
+	return &kedav1alpha1.ClusterTriggerAuthentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterTriggerAuthenticationName,
+		},
+		Spec: kedav1alpha1.ClusterTriggerAuthenticationSpec{
+			TriggerAuthenticationSpec: kedav1alpha1.TriggerAuthenticationSpec{
+				SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{
+					{
+						Parameter: "host",
+						Name:      secretName,
+						Key:       secretKey,
+					},
+				},
+			},
+			AllowedNamespaces: allowedNamespaces,
+			NamespaceSelector: namespaceSelector,
+		},
+	}
+}
+
+func newClusterObjectNamespaceSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: GetClusterObjectNamespace(),
+			Name:      secretName,
+		},
+		Data: map[string][]byte{secretKey: []byte(secretData)},
+	}
+}
+
 func TestResolveDependentEnv(t *testing.T) {
 	tests := []struct {
 		name      string
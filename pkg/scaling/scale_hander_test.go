@@ -1,11 +1,20 @@
 package scaling
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/metrics/pkg/apis/external_metrics"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/scaling/cache"
+	"github.com/kedacore/keda/pkg/scaling/modifiers"
 )
 
 func TestTargetAverageValue(t *testing.T) {
@@ -49,3 +58,92 @@ func createMetricSpec(averageValue int) v2beta2.MetricSpec {
 		},
 	}
 }
+
+func newTestCustomResource(spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "ConsumerGroup",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "test-ns", "generation": int64(2)},
+		"spec":       spec,
+	}}
+}
+
+func TestAsDuckWithTriggersUnstructured(t *testing.T) {
+	object := newTestCustomResource(map[string]interface{}{
+		"pollingInterval": int64(10),
+		"triggers": []interface{}{
+			map[string]interface{}{"type": "kafka", "metadata": map[string]interface{}{"topic": "orders"}},
+		},
+		"replicas": int64(3),
+	})
+
+	withTriggers, err := asDuckWithTriggers(object)
+	assert.NoError(t, err)
+	assert.Equal(t, "ConsumerGroup", withTriggers.Kind)
+	assert.Equal(t, "test-ns", withTriggers.Namespace)
+	assert.Equal(t, int64(2), withTriggers.Generation)
+	assert.Equal(t, int32(10), *withTriggers.Spec.PollingInterval)
+	assert.Len(t, withTriggers.Spec.Triggers, 1)
+	assert.Equal(t, "kafka", withTriggers.Spec.Triggers[0].Type)
+	assert.Equal(t, "orders", withTriggers.Spec.Triggers[0].Metadata["topic"])
+	assert.Equal(t, "ConsumerGroup.test-ns.test", generateKey(withTriggers))
+}
+
+func TestGetPodsUnstructured(t *testing.T) {
+	h := &scaleHandler{logger: logf.Log.WithName("test")}
+
+	podTemplateSpec, containerName, err := h.getPods(newTestCustomResource(map[string]interface{}{}))
+	assert.NoError(t, err)
+	assert.Nil(t, podTemplateSpec)
+	assert.Equal(t, "", containerName)
+
+	podTemplateSpec, containerName, err = h.getPods(newTestCustomResource(map[string]interface{}{
+		"envSourceContainerName": "consumer",
+		"template": map[string]interface{}{
+			"spec": map[string]interface{}{
+				"containers": []interface{}{map[string]interface{}{"name": "consumer", "image": "consumer:latest"}},
+			},
+		},
+	}))
+	assert.NoError(t, err)
+	assert.Len(t, podTemplateSpec.Spec.Containers, 1)
+	assert.Equal(t, "consumer", containerName)
+}
+
+type fakeExternalScaler struct {
+	value int64
+}
+
+func (s *fakeExternalScaler) GetMetrics(ctx context.Context, metricName string, metricSelector labels.Selector) ([]external_metrics.ExternalMetricValue, error) {
+	return []external_metrics.ExternalMetricValue{{MetricName: metricName, Value: *resource.NewQuantity(s.value, resource.DecimalSI)}}, nil
+}
+
+func (s *fakeExternalScaler) GetMetricSpecForScaling() []v2beta2.MetricSpec {
+	return []v2beta2.MetricSpec{{External: &v2beta2.ExternalMetricSource{Metric: v2beta2.MetricIdentifier{Name: "metric"}}}}
+}
+
+func (s *fakeExternalScaler) IsActive(ctx context.Context) (bool, error) { return s.value > 0, nil }
+func (s *fakeExternalScaler) Close() error                               { return nil }
+
+func TestGetCompositeMetricValueUsesCompiledFormula(t *testing.T) {
+	h := &scaleHandler{logger: logf.Log.WithName("test")}
+	scaledObject := &kedav1alpha1.ScaledObject{Spec: kedav1alpha1.ScaledObjectSpec{
+		Triggers: []kedav1alpha1.ScaleTriggers{{Name: "a"}, {Name: "b"}},
+		Advanced: &kedav1alpha1.AdvancedConfig{ScalingModifiers: &kedav1alpha1.ScalingModifiers{Formula: "a + b"}},
+	}}
+	scalersCache := cache.NewScalersCache(1, 0, logf.Log.WithName("test"), []cache.ScalerBuilder{
+		{Scaler: &fakeExternalScaler{value: 2}}, {Scaler: &fakeExternalScaler{value: 3}},
+	})
+
+	_, err := h.getCompositeMetricValue(context.TODO(), scaledObject, scalersCache)
+	assert.Error(t, err, "the formula is compiled when the scalers are built")
+
+	// the compiled formula is evaluated, not the one in the spec
+	scalersCache.Formula, err = modifiers.Compile("a * b")
+	assert.NoError(t, err)
+	value, err := h.getCompositeMetricValue(context.TODO(), scaledObject, scalersCache)
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, value)
+
+	assert.Equal(t, 2, modifiers.GetCompositeScalerIndex(scaledObject))
+}
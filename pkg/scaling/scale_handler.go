@@ -3,24 +3,34 @@ package scaling
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/mitchellh/hashstructure"
 	"k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/scale"
+	"k8s.io/metrics/pkg/apis/external_metrics"
 	"knative.dev/pkg/apis/duck"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/fallback"
 	"github.com/kedacore/keda/pkg/scalers"
+	"github.com/kedacore/keda/pkg/scaling/cache"
 	"github.com/kedacore/keda/pkg/scaling/executor"
+	"github.com/kedacore/keda/pkg/scaling/modifiers"
 	"github.com/kedacore/keda/pkg/scaling/resolver"
 )
 
@@ -34,27 +44,50 @@ const (
 type ScaleHandler interface {
 	HandleScalableObject(scalableObject interface{}) error
 	DeleteScalableObject(scalableObject interface{}) error
-	GetScalers(scalableObject interface{}) ([]scalers.Scaler, error)
+	// GetScalersCache returns the scalers of the scalableObject, the caller must Release the returned cache
+	GetScalersCache(scalableObject interface{}) (*cache.ScalersCache, error)
+	ClearScalersCache(scalableObject interface{}) error
+	GetScaledObjectMetrics(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metricName string, metricSelector labels.Selector) ([]ScalerMetricsResult, error)
+	ValidateScalableObject(scalableObject interface{}) error
+}
+
+// ScalerMetricsResult holds the metrics (or an error) returned by a single scaler
+type ScalerMetricsResult struct {
+	ScalerName  string
+	ScalerIndex int
+	Metrics     []external_metrics.ExternalMetricValue
+	Err         error
 }
 
 type scaleHandler struct {
 	client            client.Client
+	apiReader         client.Reader
 	logger            logr.Logger
 	scaleLoopContexts *sync.Map
 	scaleExecutor     executor.ScaleExecutor
+	scalerCaches      map[string]*cache.ScalersCache
+	scalerCachesLock  *sync.RWMutex
 }
 
-// NewScaleHandler creates a ScaleHandler object
-func NewScaleHandler(client client.Client, scaleClient *scale.ScalesGetter, reconcilerScheme *runtime.Scheme) ScaleHandler {
+// NewScaleHandler creates a ScaleHandler object, apiReader reads objects configuring scalers (eg. ConfigMaps)
+// directly from the API server
+func NewScaleHandler(client client.Client, apiReader client.Reader, scaleClient *scale.ScalesGetter, reconcilerScheme *runtime.Scheme) ScaleHandler {
 	return &scaleHandler{
 		client:            client,
+		apiReader:         apiReader,
 		logger:            logf.Log.WithName("scalehandler"),
 		scaleLoopContexts: &sync.Map{},
 		scaleExecutor:     executor.NewScaleExecutor(client, scaleClient, reconcilerScheme),
+		scalerCaches:      map[string]*cache.ScalersCache{},
+		scalerCachesLock:  &sync.RWMutex{},
 	}
 }
 
-func (h *scaleHandler) GetScalers(scalableObject interface{}) ([]scalers.Scaler, error) {
+// GetScalersCache returns live scalers for the specified scalableObject. Scalers are reused as long as
+// the object's Generation and the resolved scalers configuration (trigger metadata, referenced
+// TriggerAuthentication and resolved secrets) stay the same, otherwise they are rebuilt. The returned cache
+// is held by the caller until it calls Release, a replaced cache is only closed once all its holders released it.
+func (h *scaleHandler) GetScalersCache(scalableObject interface{}) (*cache.ScalersCache, error) {
 	withTriggers, err := asDuckWithTriggers(scalableObject)
 	if err != nil {
 		return nil, err
@@ -65,7 +98,140 @@ func (h *scaleHandler) GetScalers(scalableObject interface{}) ([]scalers.Scaler,
 		return nil, err
 	}
 
-	return h.buildScalers(withTriggers, podTemplateSpec, containerName)
+	configs, err := h.resolveScalersConfig(withTriggers, podTemplateSpec, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	configHash, err := hashstructure.Hash(configs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error computing hash of scalers configuration: %s", err)
+	}
+
+	key := generateKey(withTriggers)
+
+	h.scalerCachesLock.RLock()
+	if c, ok := h.scalerCaches[key]; ok && c.Generation == withTriggers.Generation && c.ConfigHash == configHash {
+		c.Acquire()
+		h.scalerCachesLock.RUnlock()
+		return c, nil
+	}
+	h.scalerCachesLock.RUnlock()
+
+	// the formula is compiled once together with the scalers, it changes only with the Generation
+	var formula *modifiers.Formula
+	if scaledObject, ok := scalableObject.(*kedav1alpha1.ScaledObject); ok {
+		if scalingModifiers := modifiers.GetScalingModifiers(scaledObject); scalingModifiers != nil {
+			formula, err = modifiers.Compile(scalingModifiers.Formula)
+			if err != nil {
+				return nil, fmt.Errorf("error compiling scalingModifiers formula: %s", err)
+			}
+		}
+	}
+
+	builders, err := h.buildScalers(configs)
+	if err != nil {
+		return nil, err
+	}
+	newCache := cache.NewScalersCache(withTriggers.Generation, configHash, h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name), builders)
+	newCache.Formula = formula
+
+	h.scalerCachesLock.Lock()
+	defer h.scalerCachesLock.Unlock()
+	if oldCache, ok := h.scalerCaches[key]; ok {
+		if oldCache.Generation == withTriggers.Generation && oldCache.ConfigHash == configHash {
+			// another caller has built the scalers for the same configuration in the meantime
+			oldCache.Acquire()
+			newCache.Close()
+			return oldCache, nil
+		}
+		h.logger.V(1).Info("Scalers configuration has changed, closing the outdated scalers", "key", key)
+		oldCache.Close()
+	}
+	newCache.Acquire()
+	h.scalerCaches[key] = newCache
+
+	return newCache, nil
+}
+
+// ClearScalersCache closes and removes cached scalers for the specified scalableObject
+func (h *scaleHandler) ClearScalersCache(scalableObject interface{}) error {
+	withTriggers, err := asDuckWithTriggers(scalableObject)
+	if err != nil {
+		return err
+	}
+
+	key := generateKey(withTriggers)
+
+	h.scalerCachesLock.Lock()
+	defer h.scalerCachesLock.Unlock()
+	if c, ok := h.scalerCaches[key]; ok {
+		c.Close()
+		delete(h.scalerCaches, key)
+	}
+
+	return nil
+}
+
+// GetScaledObjectMetrics returns the requested metric from all scalers of the ScaledObject that expose it
+func (h *scaleHandler) GetScaledObjectMetrics(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, metricName string, metricSelector labels.Selector) ([]ScalerMetricsResult, error) {
+	scalersCache, err := h.GetScalersCache(scaledObject)
+	if err != nil {
+		return nil, fmt.Errorf("error when getting scalers %s", err)
+	}
+	defer scalersCache.Release()
+
+	if scalingModifiers := modifiers.GetScalingModifiers(scaledObject); scalingModifiers != nil {
+		if !strings.EqualFold(metricName, modifiers.CompositeMetricName) {
+			return nil, nil
+		}
+		var metrics []external_metrics.ExternalMetricValue
+		value, err := h.getCompositeMetricValue(ctx, scaledObject, scalersCache)
+		if err == nil {
+			metrics = []external_metrics.ExternalMetricValue{{
+				MetricName: modifiers.CompositeMetricName,
+				Value:      *resource.NewMilliQuantity(int64(value*1000), resource.DecimalSI),
+				Timestamp:  metav1.Now(),
+			}}
+		} else if fallback.IsEnabled(scaledObject) {
+			metricSpec, specErr := modifiers.GetCompositeMetricSpec(scaledObject)
+			if specErr == nil {
+				metrics, err = fallback.GetMetricsWithFallback(scaledObject, nil, err, modifiers.CompositeMetricName, metricSpec)
+			}
+		}
+		if err != nil {
+			h.logger.Error(err, "error getting composite metric", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+		}
+		return []ScalerMetricsResult{{ScalerName: modifiers.CompositeScalerName, ScalerIndex: modifiers.GetCompositeScalerIndex(scaledObject), Metrics: metrics, Err: err}}, nil
+	}
+
+	var results []ScalerMetricsResult
+	for scalerIndex, scaler := range scalersCache.GetScalers() {
+		scalerName := strings.Replace(fmt.Sprintf("%T", scaler), "*scalers.", "", 1)
+
+		for _, metricSpec := range scaler.GetMetricSpecForScaling() {
+			// skip cpu/memory resource scaler
+			if metricSpec.External == nil {
+				continue
+			}
+			// Filter only the desired metric
+			if strings.EqualFold(metricSpec.External.Metric.Name, metricName) {
+				metrics, err := scalersCache.GetMetricsForScaler(ctx, scalerIndex, metricName, metricSelector)
+				metrics, err = fallback.GetMetricsWithFallback(scaledObject, metrics, err, metricName, metricSpec)
+				if err != nil {
+					h.logger.Error(err, "error getting metric for scaler", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name, "scaler", scalerName)
+				}
+				results = append(results, ScalerMetricsResult{
+					ScalerName:  scalerName,
+					ScalerIndex: scalerIndex,
+					Metrics:     metrics,
+					Err:         err,
+				})
+			}
+		}
+	}
+
+	return results, nil
 }
 
 func (h *scaleHandler) HandleScalableObject(scalableObject interface{}) error {
@@ -116,7 +282,7 @@ func (h *scaleHandler) DeleteScalableObject(scalableObject interface{}) error {
 		h.logger.V(1).Info("ScaleObject was not found in controller cache", "key", key)
 	}
 
-	return nil
+	return h.ClearScalersCache(scalableObject)
 }
 
 // startScaleLoop blocks forever and checks the scaledObject based on its pollingInterval
@@ -142,18 +308,19 @@ func (h *scaleHandler) startScaleLoop(ctx context.Context, withTriggers *kedav1a
 
 func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav1alpha1.WithTriggers, scalableObject interface{}, scalingMutex sync.Locker) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
-	ss, err := h.GetScalers(scalableObject)
+	scalersCache, err := h.GetScalersCache(scalableObject)
 	if err != nil {
 		logger.Error(err, "Error getting scalers", "object", scalableObject)
 		return
 	}
-
-	for _, s := range ss {
-		scaler, ok := s.(scalers.PushScaler)
-		if !ok {
-			continue
-		}
-
+	// the push scalers are used until the scale loop is stopped
+	go func() {
+		<-ctx.Done()
+		scalersCache.Release()
+	}()
+
+	for _, ps := range scalersCache.GetPushScalers() {
+		scaler := ps
 		go func() {
 			activeCh := make(chan bool)
 			go scaler.Run(ctx, activeCh)
@@ -165,9 +332,11 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 					scalingMutex.Lock()
 					switch obj := scalableObject.(type) {
 					case *kedav1alpha1.ScaledObject:
-						h.scaleExecutor.RequestScale(ctx, obj, active)
+						h.scaleExecutor.RequestScale(ctx, obj, active, false)
 					case *kedav1alpha1.ScaledJob:
 						h.logger.Info("Warning: External Push Scaler does not support ScaledJob", "object", scalableObject)
+					case *unstructured.Unstructured:
+						h.scaleExecutor.RequestDuckScale(ctx, obj, active)
 					}
 					scalingMutex.Unlock()
 				}
@@ -179,54 +348,164 @@ func (h *scaleHandler) startPushScalers(ctx context.Context, withTriggers *kedav
 // checkScalers contains the main logic for the ScaleHandler scaling logic.
 // It'll check each trigger active status then call RequestScale
 func (h *scaleHandler) checkScalers(ctx context.Context, scalableObject interface{}, scalingMutex sync.Locker) {
-	scalers, err := h.GetScalers(scalableObject)
+	scalersCache, err := h.GetScalersCache(scalableObject)
 	if err != nil {
 		h.logger.Error(err, "Error getting scalers", "object", scalableObject)
 		return
 	}
+	defer scalersCache.Release()
 
 	scalingMutex.Lock()
 	defer scalingMutex.Unlock()
 	switch obj := scalableObject.(type) {
 	case *kedav1alpha1.ScaledObject:
-		h.scaleExecutor.RequestScale(ctx, obj, h.checkScaledObjectScalers(ctx, scalers))
+		isActive, isError := h.checkScaledObjectScalers(ctx, scalersCache, obj)
+		h.scaleExecutor.RequestScale(ctx, obj, isActive, isError)
 	case *kedav1alpha1.ScaledJob:
 		scaledJob := scalableObject.(*kedav1alpha1.ScaledJob)
-		isActive, scaleTo, maxScale := h.checkScaledJobScalers(ctx, scalers, scaledJob)
+		isActive, scaleTo, maxScale := h.checkScaledJobScalers(ctx, scalersCache, scaledJob)
 		h.scaleExecutor.RequestJobScale(ctx, obj, isActive, scaleTo, maxScale)
+	case *unstructured.Unstructured:
+		isActive := h.checkDuckScalers(ctx, scalersCache, obj)
+		h.scaleExecutor.RequestDuckScale(ctx, obj, isActive)
 	}
 }
 
-func (h *scaleHandler) checkScaledObjectScalers(ctx context.Context, scalers []scalers.Scaler) bool {
+// checkScaledObjectScalers returns whether any trigger of the ScaledObject is active and whether any trigger failed,
+// if fallback is enabled the failures are recorded per trigger metric in the ScaledObject's health status
+func (h *scaleHandler) checkScaledObjectScalers(ctx context.Context, scalersCache *cache.ScalersCache, scaledObject *kedav1alpha1.ScaledObject) (bool, bool) {
+	status := scaledObject.Status.DeepCopy()
+	isFallbackEnabled := fallback.IsEnabled(scaledObject)
 	isActive := false
-	for _, scaler := range scalers {
-		isTriggerActive, err := scaler.IsActive(ctx)
-		scaler.Close()
+	isError := false
+	var metricNames []string
 
+	if scalingModifiers := modifiers.GetScalingModifiers(scaledObject); scalingModifiers != nil {
+		_, activationTarget, err := modifiers.GetTargets(scalingModifiers)
 		if err != nil {
 			h.logger.V(1).Info("Error getting scale decision", "Error", err)
-			continue
-		} else if isTriggerActive {
+			return false, true
+		}
+		value, err := h.getCompositeMetricValue(ctx, scaledObject, scalersCache)
+		metricNames = append(metricNames, modifiers.CompositeMetricName)
+		if isFallbackEnabled {
+			fallback.UpdateHealthStatus(status, modifiers.CompositeMetricName, err)
+		}
+		if err != nil {
+			h.logger.V(1).Info("Error getting scale decision", "Error", err)
+			isError = true
+		} else if value > activationTarget {
 			isActive = true
-			if scaler.GetMetricSpecForScaling()[0].External != nil {
-				h.logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", scaler.GetMetricSpecForScaling()[0].External.Metric.Name)
+			h.logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", modifiers.CompositeMetricName)
+		}
+	} else {
+		for i, scaler := range scalersCache.GetScalers() {
+			isTriggerActive, err := scalersCache.IsScalerActive(ctx, i)
+
+			metricSpecs := scaler.GetMetricSpecForScaling()
+			for _, metricSpec := range metricSpecs {
+				if metricSpec.External != nil {
+					metricNames = append(metricNames, metricSpec.External.Metric.Name)
+				}
+			}
+			if isFallbackEnabled && len(metricSpecs) > 0 && metricSpecs[0].External != nil {
+				fallback.UpdateHealthStatus(status, metricSpecs[0].External.Metric.Name, err)
 			}
-			if scaler.GetMetricSpecForScaling()[0].Resource != nil {
-				h.logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", scaler.GetMetricSpecForScaling()[0].Resource.Name)
+
+			if err != nil {
+				h.logger.V(1).Info("Error getting scale decision", "Error", err)
+				isError = true
+				continue
+			} else if isTriggerActive {
+				isActive = true
+				if metricSpecs[0].External != nil {
+					h.logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", metricSpecs[0].External.Metric.Name)
+				}
+				if metricSpecs[0].Resource != nil {
+					h.logger.V(1).Info("Scaler for scaledObject is active", "Metrics Name", metricSpecs[0].Resource.Name)
+				}
+				// all triggers have to be checked to keep track of their failures
+				if !isFallbackEnabled {
+					break
+				}
 			}
-			break
 		}
 	}
-	return isActive
+
+	if isFallbackEnabled {
+		// drop the health status of metrics of removed triggers, they would keep the fallback active otherwise
+		fallback.PruneHealthStatus(status, metricNames)
+	}
+	if isFallbackEnabled && !equality.Semantic.DeepEqual(scaledObject.Status.Health, status.Health) {
+		patch := client.MergeFrom(scaledObject.DeepCopy())
+		scaledObject.Status.Health = status.Health
+		if err := h.client.Status().Patch(ctx, scaledObject, patch); err != nil {
+			h.logger.Error(err, "Error updating health status of ScaledObject", "scaledObject.Namespace", scaledObject.Namespace, "scaledObject.Name", scaledObject.Name)
+		}
+	}
+
+	return isActive, isError
 }
 
-func (h *scaleHandler) checkScaledJobScalers(ctx context.Context, scalers []scalers.Scaler, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64) {
+// checkDuckScalers returns whether any trigger of the custom resource with triggers is active
+func (h *scaleHandler) checkDuckScalers(ctx context.Context, scalersCache *cache.ScalersCache, object *unstructured.Unstructured) bool {
+	for i := range scalersCache.GetScalers() {
+		isTriggerActive, err := scalersCache.IsScalerActive(ctx, i)
+		if err != nil {
+			h.logger.V(1).Info("Error getting scale decision", "Error", err, "kind", object.GetKind(), "namespace", object.GetNamespace(), "name", object.GetName())
+			continue
+		}
+		if isTriggerActive {
+			return true
+		}
+	}
+	return false
+}
+
+// getCompositeMetricValue evaluates the scalingModifiers formula compiled with the scalers,
+// each named trigger contributes the sum of its metric values
+func (h *scaleHandler) getCompositeMetricValue(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, scalersCache *cache.ScalersCache) (float64, error) {
+	formula := scalersCache.Formula
+	if formula == nil {
+		return 0, fmt.Errorf("scalingModifiers formula of ScaledObject %s/%s is not compiled", scaledObject.Namespace, scaledObject.Name)
+	}
+
+	referenced := map[string]bool{}
+	for _, name := range formula.Identifiers() {
+		referenced[name] = true
+	}
+
+	values := map[string]float64{}
+	cachedScalers := scalersCache.GetScalers()
+	for i, trigger := range scaledObject.Spec.Triggers {
+		if !referenced[trigger.Name] || i >= len(cachedScalers) {
+			continue
+		}
+		metricSpecs := cachedScalers[i].GetMetricSpecForScaling()
+		if len(metricSpecs) == 0 || metricSpecs[0].External == nil {
+			return 0, fmt.Errorf("trigger %s doesn't expose an external metric", trigger.Name)
+		}
+		metrics, err := scalersCache.GetMetricsForScaler(ctx, i, metricSpecs[0].External.Metric.Name, nil)
+		if err != nil {
+			return 0, fmt.Errorf("error getting metrics for trigger %s: %s", trigger.Name, err)
+		}
+		sum := 0.0
+		for _, metric := range metrics {
+			sum += float64(metric.Value.MilliValue()) / 1000
+		}
+		values[trigger.Name] = sum
+	}
+
+	return formula.Evaluate(values)
+}
+
+func (h *scaleHandler) checkScaledJobScalers(ctx context.Context, scalersCache *cache.ScalersCache, scaledJob *kedav1alpha1.ScaledJob) (bool, int64, int64) {
 	var queueLength int64
 	var targetAverageValue int64
 	var maxValue int64
 	isActive := false
 
-	for _, scaler := range scalers {
+	for i, scaler := range scalersCache.GetScalers() {
 		scalerLogger := h.logger.WithValues("Scaler", scaler)
 
 		metricSpecs := scaler.GetMetricSpecForScaling()
@@ -235,7 +514,7 @@ func (h *scaleHandler) checkScaledJobScalers(ctx context.Context, scalers []scal
 			continue
 		}
 
-		isTriggerActive, err := scaler.IsActive(ctx)
+		isTriggerActive, err := scalersCache.IsScalerActive(ctx, i)
 
 		scalerLogger.Info("Active trigger", "isTriggerActive", isTriggerActive)
 
@@ -243,7 +522,7 @@ func (h *scaleHandler) checkScaledJobScalers(ctx context.Context, scalers []scal
 
 		scalerLogger.Info("Scaler targetAverageValue", "targetAverageValue", targetAverageValue)
 
-		metrics, _ := scaler.GetMetrics(ctx, "queueLength", nil)
+		metrics, _ := scalersCache.GetMetricsForScaler(ctx, i, "queueLength", nil)
 
 		var metricValue int64
 
@@ -255,7 +534,6 @@ func (h *scaleHandler) checkScaledJobScalers(ctx context.Context, scalers []scal
 		}
 		scalerLogger.Info("QueueLength Metric value", "queueLength", queueLength)
 
-		scaler.Close()
 		if err != nil {
 			scalerLogger.V(1).Info("Error getting scale decision, but continue", "Error", err)
 			continue
@@ -311,56 +589,115 @@ func min(x, y int64) int64 {
 	return x
 }
 
-// buildScalers returns list of Scalers for the specified triggers
-func (h *scaleHandler) buildScalers(withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string) ([]scalers.Scaler, error) {
+// scalerConfig holds everything needed to build a scaler for a single trigger,
+// it is also used to detect changes in the resolved configuration
+type scalerConfig struct {
+	TriggerType string
+	Config      scalers.ScalerConfig
+}
+
+// resolveScalersConfig resolves environment and authentication for the specified triggers
+func (h *scaleHandler) resolveScalersConfig(withTriggers *kedav1alpha1.WithTriggers, podTemplateSpec *corev1.PodTemplateSpec, containerName string) ([]scalerConfig, error) {
 	logger := h.logger.WithValues("type", withTriggers.Kind, "namespace", withTriggers.Namespace, "name", withTriggers.Name)
-	var scalersRes []scalers.Scaler
+	var configs []scalerConfig
 	var err error
 	resolvedEnv := make(map[string]string)
 	if podTemplateSpec != nil {
 		resolvedEnv, err = resolver.ResolveContainerEnv(h.client, logger, &podTemplateSpec.Spec, containerName, withTriggers.Namespace)
 		if err != nil {
-			return scalersRes, fmt.Errorf("error resolving secrets for ScaleTarget: %s", err)
+			return nil, fmt.Errorf("error resolving secrets for ScaleTarget: %s", err)
 		}
 	}
 
-	for i, trigger := range withTriggers.Spec.Triggers {
-		config := &scalers.ScalerConfig{
+	for _, trigger := range withTriggers.Spec.Triggers {
+		config := scalers.ScalerConfig{
 			Name:            withTriggers.Name,
 			Namespace:       withTriggers.Namespace,
 			TriggerMetadata: trigger.Metadata,
 			ResolvedEnv:     resolvedEnv,
 			AuthParams:      make(map[string]string),
+			KubeClient:      h.apiReader,
+			PollingInterval: getPollingInterval(withTriggers),
 		}
+		var podSpec *corev1.PodSpec
 		if podTemplateSpec != nil {
-			authParams, podIdentity := resolver.ResolveAuthRef(h.client, logger, trigger.AuthenticationRef, &podTemplateSpec.Spec, withTriggers.Namespace)
+			podSpec = &podTemplateSpec.Spec
+		}
+		authParams, podIdentity := resolver.ResolveAuthRef(h.client, logger, trigger.AuthenticationRef, podSpec, withTriggers.Namespace)
 
+		if podTemplateSpec != nil {
 			if podIdentity == kedav1alpha1.PodIdentityProviderAwsEKS {
 				serviceAccountName := podTemplateSpec.Spec.ServiceAccountName
 				serviceAccount := &corev1.ServiceAccount{}
 				err = h.client.Get(context.TODO(), types.NamespacedName{Name: serviceAccountName, Namespace: withTriggers.Namespace}, serviceAccount)
 				if err != nil {
-					closeScalers(scalersRes)
-					return []scalers.Scaler{}, fmt.Errorf("error getting service account: %s", err)
+					return nil, fmt.Errorf("error getting service account: %s", err)
 				}
 				authParams["awsRoleArn"] = serviceAccount.Annotations[kedav1alpha1.PodIdentityAnnotationEKS]
 			} else if podIdentity == kedav1alpha1.PodIdentityProviderAwsKiam {
 				authParams["awsRoleArn"] = podTemplateSpec.ObjectMeta.Annotations[kedav1alpha1.PodIdentityAnnotationKiam]
 			}
-			config.AuthParams = authParams
-			config.PodIdentity = podIdentity
 		}
+		config.AuthParams = authParams
+		config.PodIdentity = podIdentity
+
+		configs = append(configs, scalerConfig{TriggerType: trigger.Type, Config: config})
+	}
 
-		scaler, err := buildScaler(trigger.Type, config)
+	return configs, nil
+}
+
+// ValidateScalableObject resolves the configuration of all triggers and validates their metadata,
+// scalers are not built, so no connection to the scaled sources is made
+func (h *scaleHandler) ValidateScalableObject(scalableObject interface{}) error {
+	withTriggers, err := asDuckWithTriggers(scalableObject)
+	if err != nil {
+		return err
+	}
+
+	podTemplateSpec, containerName, err := h.getPods(scalableObject)
+	if err != nil {
+		return err
+	}
+
+	configs, err := h.resolveScalersConfig(withTriggers, podTemplateSpec, containerName)
+	if err != nil {
+		return err
+	}
+
+	for i, c := range configs {
+		if err := scalers.ValidateTriggerMetadata(c.TriggerType, &c.Config); err != nil {
+			return fmt.Errorf("error parsing metadata of trigger #%d (%s): %s", i, c.TriggerType, err)
+		}
+	}
+
+	return nil
+}
+
+// buildScalers returns list of Scalers for the specified triggers
+func (h *scaleHandler) buildScalers(configs []scalerConfig) ([]cache.ScalerBuilder, error) {
+	var builders []cache.ScalerBuilder
+
+	for i, c := range configs {
+		triggerType := c.TriggerType
+		config := c.Config
+		factory := func() (scalers.Scaler, error) {
+			return scalers.BuildScaler(triggerType, &config)
+		}
+
+		scaler, err := factory()
 		if err != nil {
-			closeScalers(scalersRes)
-			return []scalers.Scaler{}, fmt.Errorf("error getting scaler for trigger #%d: %s", i, err)
+			closeScalers(builders)
+			return []cache.ScalerBuilder{}, fmt.Errorf("error getting scaler for trigger #%d: %s", i, err)
 		}
 
-		scalersRes = append(scalersRes, scaler)
+		builders = append(builders, cache.ScalerBuilder{
+			Scaler:  scaler,
+			Factory: factory,
+		})
 	}
 
-	return scalersRes, nil
+	return builders, nil
 }
 
 func (h *scaleHandler) getPods(scalableObject interface{}) (*corev1.PodTemplateSpec, string, error) {
@@ -391,74 +728,26 @@ func (h *scaleHandler) getPods(scalableObject interface{}) (*corev1.PodTemplateS
 		return &podTemplateSpec, obj.Spec.ScaleTargetRef.EnvSourceContainerName, nil
 	case *kedav1alpha1.ScaledJob:
 		return &obj.Spec.JobTargetRef.Template, obj.Spec.EnvSourceContainerName, nil
-	default:
-		return nil, "", fmt.Errorf("unknown scalable object type %v", scalableObject)
-	}
-}
+	case *unstructured.Unstructured:
+		// custom resources with triggers may embed a pod template in spec.template
+		withPods := &duckv1.WithPod{}
+		if err := duck.FromUnstructured(obj, withPods); err != nil {
+			h.logger.Error(err, "Cannot convert unstructured into PodSpecable Duck-type", "object", obj)
+		}
 
-func buildScaler(triggerType string, config *scalers.ScalerConfig) (scalers.Scaler, error) {
-	// TRIGGERS-START
-	switch triggerType {
-	case "artemis-queue":
-		return scalers.NewArtemisQueueScaler(config)
-	case "aws-cloudwatch":
-		return scalers.NewAwsCloudwatchScaler(config)
-	case "aws-kinesis-stream":
-		return scalers.NewAwsKinesisStreamScaler(config)
-	case "aws-sqs-queue":
-		return scalers.NewAwsSqsQueueScaler(config)
-	case "azure-blob":
-		return scalers.NewAzureBlobScaler(config)
-	case "azure-eventhub":
-		return scalers.NewAzureEventHubScaler(config)
-	case "azure-log-analytics":
-		return scalers.NewAzureLogAnalyticsScaler(config)
-	case "azure-monitor":
-		return scalers.NewAzureMonitorScaler(config)
-	case "azure-queue":
-		return scalers.NewAzureQueueScaler(config)
-	case "azure-servicebus":
-		return scalers.NewAzureServiceBusScaler(config)
-	case "cpu":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceCPU, config)
-	case "cron":
-		return scalers.NewCronScaler(config)
-	case "external":
-		return scalers.NewExternalScaler(config)
-	case "external-push":
-		return scalers.NewExternalPushScaler(config)
-	case "gcp-pubsub":
-		return scalers.NewPubSubScaler(config)
-	case "huawei-cloudeye":
-		return scalers.NewHuaweiCloudeyeScaler(config)
-	case "ibmmq":
-		return scalers.NewIBMMQScaler(config)
-	case "kafka":
-		return scalers.NewKafkaScaler(config)
-	case "liiklus":
-		return scalers.NewLiiklusScaler(config)
-	case "memory":
-		return scalers.NewCPUMemoryScaler(corev1.ResourceMemory, config)
-	case "metrics-api":
-		return scalers.NewMetricsAPIScaler(config)
-	case "mysql":
-		return scalers.NewMySQLScaler(config)
-	case "postgresql":
-		return scalers.NewPostgreSQLScaler(config)
-	case "prometheus":
-		return scalers.NewPrometheusScaler(config)
-	case "rabbitmq":
-		return scalers.NewRabbitMQScaler(config)
-	case "redis":
-		return scalers.NewRedisScaler(config)
-	case "redis-streams":
-		return scalers.NewRedisStreamsScaler(config)
-	case "stan":
-		return scalers.NewStanScaler(config)
+		if withPods.Spec.Template.Spec.Containers == nil {
+			return nil, "", nil
+		}
+
+		containerName, _, _ := unstructured.NestedString(obj.Object, "spec", "envSourceContainerName")
+		podTemplateSpec := corev1.PodTemplateSpec{
+			ObjectMeta: withPods.Spec.Template.ObjectMeta,
+			Spec:       withPods.Spec.Template.Spec,
+		}
+		return &podTemplateSpec, containerName, nil
 	default:
-		return nil, fmt.Errorf("no scaler found for type: %s", triggerType)
+		return nil, "", fmt.Errorf("unknown scalable object type %v", scalableObject)
 	}
-	// TRIGGERS-END
 }
 
 func asDuckWithTriggers(scalableObject interface{}) (*kedav1alpha1.WithTriggers, error) {
@@ -481,15 +770,21 @@ func asDuckWithTriggers(scalableObject interface{}) (*kedav1alpha1.WithTriggers,
 				Triggers:        obj.Spec.Triggers,
 			},
 		}, nil
+	case *unstructured.Unstructured:
+		// custom resources implementing the WithTriggers duck type
+		withTriggers := &kedav1alpha1.WithTriggers{}
+		if err := duck.FromUnstructured(obj, withTriggers); err != nil {
+			return nil, fmt.Errorf("error converting %s into WithTriggers duck type: %s", obj.GetKind(), err)
+		}
+		return withTriggers, nil
 	default:
-		// here could be the conversion from unknown Duck type potentially in the future
 		return nil, fmt.Errorf("unknown scalable object type %v", scalableObject)
 	}
 }
 
-func closeScalers(scalers []scalers.Scaler) {
-	for _, scaler := range scalers {
-		defer scaler.Close()
+func closeScalers(builders []cache.ScalerBuilder) {
+	for _, builder := range builders {
+		defer builder.Scaler.Close()
 	}
 }
 
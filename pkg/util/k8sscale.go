@@ -0,0 +1,23 @@
+package util
+
+import (
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/scale"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// InitScaleClient creates a new Scale Client for the passed Manager instance
+func InitScaleClient(mgr manager.Manager) (scale.ScalesGetter, error) {
+	clientset, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	scaleKindResolver := scale.NewDiscoveryScaleKindResolver(clientset)
+	return scale.New(
+		clientset.RESTClient(), mgr.GetRESTMapper(),
+		dynamic.LegacyAPIPathResolverFunc,
+		scaleKindResolver,
+	), nil
+}
@@ -8,10 +8,11 @@ import (
 
 // NewTLSConfig returns a *tls.Config using the given ceClient cert, ceClient key,
 // and CA certificate. If none are appropriate, a nil *tls.Config is returned.
+// The certificate of the server is verified against the CA certificate if it is given.
 func NewTLSConfig(clientCert, clientKey, caCert string) (*tls.Config, error) {
 	valid := false
 
-	config := &tls.Config{}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
 
 	if clientCert != "" && clientKey != "" {
 		cert, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
@@ -24,9 +25,10 @@ func NewTLSConfig(clientCert, clientKey, caCert string) (*tls.Config, error) {
 
 	if caCert != "" {
 		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
+		if !caCertPool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("no certificate found in ca")
+		}
 		config.RootCAs = caCertPool
-		config.InsecureSkipVerify = true
 		valid = true
 	}
 
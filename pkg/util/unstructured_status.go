@@ -0,0 +1,74 @@
+package util
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+// GetUnstructuredCondition returns the condition of the given type from status.conditions of a custom resource,
+// if the condition is not present, a condition with status Unknown is returned
+func GetUnstructuredCondition(object *unstructured.Unstructured, conditionType kedav1alpha1.ConditionType) kedav1alpha1.Condition {
+	condition := kedav1alpha1.Condition{Type: conditionType, Status: metav1.ConditionUnknown}
+
+	conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+	if err != nil {
+		return condition
+	}
+
+	for _, c := range conditions {
+		fields, ok := c.(map[string]interface{})
+		if !ok || fields["type"] != string(conditionType) {
+			continue
+		}
+		if status, ok := fields["status"].(string); ok {
+			condition.Status = metav1.ConditionStatus(status)
+		}
+		condition.Reason, _ = fields["reason"].(string)
+		condition.Message, _ = fields["message"].(string)
+		break
+	}
+	return condition
+}
+
+// SetUnstructuredCondition sets the condition of the given type in status.conditions of a custom resource,
+// other conditions and fields of the condition unknown to KEDA (eg. observedGeneration) are preserved.
+// lastTransitionTime is set when the status changes, as required by the metav1.Condition schema.
+func SetUnstructuredCondition(object *unstructured.Unstructured, conditionType kedav1alpha1.ConditionType, status metav1.ConditionStatus, reason string, message string) error {
+	conditions, _, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+	if err != nil {
+		return err
+	}
+
+	var condition map[string]interface{}
+	for _, c := range conditions {
+		if fields, ok := c.(map[string]interface{}); ok && fields["type"] == string(conditionType) {
+			condition = fields
+			break
+		}
+	}
+	if condition == nil {
+		condition = map[string]interface{}{"type": string(conditionType)}
+		conditions = append(conditions, condition)
+	}
+
+	if condition["status"] != string(status) || condition["lastTransitionTime"] == nil {
+		condition["lastTransitionTime"] = metav1.Now().UTC().Format(time.RFC3339)
+	}
+	if _, ok := condition["observedGeneration"]; ok {
+		condition["observedGeneration"] = object.GetGeneration()
+	}
+	condition["status"] = string(status)
+	condition["reason"] = reason
+	condition["message"] = message
+
+	return unstructured.SetNestedSlice(object.Object, conditions, "status", "conditions")
+}
+
+// SetUnstructuredLastActiveTime sets status.lastActiveTime of a custom resource
+func SetUnstructuredLastActiveTime(object *unstructured.Unstructured, lastActiveTime metav1.Time) error {
+	return unstructured.SetNestedField(object.Object, lastActiveTime.UTC().Format(time.RFC3339), "status", "lastActiveTime")
+}
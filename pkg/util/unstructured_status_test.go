@@ -0,0 +1,87 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+func newTestCustomResource() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "ConsumerGroup",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "test"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Synced", "status": "True", "lastTransitionTime": "2020-10-01T00:00:00Z"},
+			},
+		},
+	}}
+}
+
+func TestGetUnstructuredConditionNotPresent(t *testing.T) {
+	condition := GetUnstructuredCondition(newTestCustomResource(), kedav1alpha1.ConditionActive)
+	assert.Equal(t, kedav1alpha1.ConditionActive, condition.Type)
+	assert.True(t, condition.IsUnknown())
+
+	condition = GetUnstructuredCondition(&unstructured.Unstructured{Object: map[string]interface{}{}}, kedav1alpha1.ConditionReady)
+	assert.True(t, condition.IsUnknown())
+}
+
+func TestSetUnstructuredCondition(t *testing.T) {
+	object := newTestCustomResource()
+
+	assert.NoError(t, SetUnstructuredCondition(object, kedav1alpha1.ConditionActive, metav1.ConditionTrue, "ScalerActive", "active"))
+	condition := GetUnstructuredCondition(object, kedav1alpha1.ConditionActive)
+	assert.True(t, condition.IsTrue())
+	assert.Equal(t, "ScalerActive", condition.Reason)
+	assert.Equal(t, "active", condition.Message)
+
+	assert.NoError(t, SetUnstructuredCondition(object, kedav1alpha1.ConditionActive, metav1.ConditionFalse, "ScalerNotActive", "not active"))
+	condition = GetUnstructuredCondition(object, kedav1alpha1.ConditionActive)
+	assert.True(t, condition.IsFalse())
+	assert.Equal(t, "ScalerNotActive", condition.Reason)
+
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	assert.Len(t, conditions, 2)
+	assert.Equal(t, "2020-10-01T00:00:00Z", conditions[0].(map[string]interface{})["lastTransitionTime"])
+}
+
+func TestSetUnstructuredConditionKeepsExistingFields(t *testing.T) {
+	object := newTestCustomResource()
+	object.SetGeneration(3)
+
+	assert.NoError(t, SetUnstructuredCondition(object, "Synced", metav1.ConditionTrue, "Synced", "synced"))
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, "2020-10-01T00:00:00Z", conditions[0].(map[string]interface{})["lastTransitionTime"], "status unchanged")
+
+	conditions[0].(map[string]interface{})["observedGeneration"] = int64(1)
+	assert.NoError(t, unstructured.SetNestedSlice(object.Object, conditions, "status", "conditions"))
+
+	assert.NoError(t, SetUnstructuredCondition(object, "Synced", metav1.ConditionFalse, "NotSynced", "not synced"))
+	conditions, _, _ = unstructured.NestedSlice(object.Object, "status", "conditions")
+	condition := conditions[0].(map[string]interface{})
+	assert.NotEqual(t, "2020-10-01T00:00:00Z", condition["lastTransitionTime"], "status changed")
+	assert.Equal(t, int64(3), condition["observedGeneration"])
+	assert.Equal(t, "NotSynced", condition["reason"])
+
+	assert.NoError(t, SetUnstructuredCondition(object, kedav1alpha1.ConditionActive, metav1.ConditionTrue, "ScalerActive", "active"))
+	conditions, _, _ = unstructured.NestedSlice(object.Object, "status", "conditions")
+	_, err := time.Parse(time.RFC3339, conditions[1].(map[string]interface{})["lastTransitionTime"].(string))
+	assert.NoError(t, err)
+}
+
+func TestSetUnstructuredLastActiveTime(t *testing.T) {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{}}
+
+	assert.NoError(t, SetUnstructuredLastActiveTime(object, metav1.NewTime(time.Date(2020, 10, 1, 12, 0, 0, 0, time.UTC))))
+	value, found, _ := unstructured.NestedString(object.Object, "status", "lastActiveTime")
+	assert.True(t, found)
+	assert.Equal(t, "2020-10-01T12:00:00Z", value)
+}
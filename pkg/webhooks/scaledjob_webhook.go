@@ -0,0 +1,50 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/scaling"
+)
+
+// scaledJobValidator rejects ScaledJobs with invalid replica count or triggers
+type scaledJobValidator struct {
+	scaleHandler scaling.ScaleHandler
+	decoder      *admission.Decoder
+	logger       logr.Logger
+}
+
+// Handle validates the ScaledJob in the admission request
+func (v *scaledJobValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	scaledJob := &kedav1alpha1.ScaledJob{}
+	if err := v.decoder.Decode(req, scaledJob); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.validate(scaledJob); err != nil {
+		v.logger.V(1).Info("Rejecting ScaledJob", "ScaledJob.Namespace", scaledJob.Namespace, "ScaledJob.Name", scaledJob.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *scaledJobValidator) validate(scaledJob *kedav1alpha1.ScaledJob) error {
+	if scaledJob.Spec.MaxReplicaCount != nil && *scaledJob.Spec.MaxReplicaCount < 0 {
+		return fmt.Errorf("maxReplicaCount (%d) must not be negative", *scaledJob.Spec.MaxReplicaCount)
+	}
+
+	if scaledJob.Spec.JobTargetRef == nil {
+		return fmt.Errorf("jobTargetRef must be specified")
+	}
+
+	if err := v.scaleHandler.ValidateScalableObject(scaledJob); err != nil {
+		return fmt.Errorf("invalid triggers: %s", err)
+	}
+
+	return nil
+}
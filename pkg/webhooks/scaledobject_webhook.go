@@ -0,0 +1,164 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/scaling"
+	"github.com/kedacore/keda/pkg/scaling/modifiers"
+	kedautil "github.com/kedacore/keda/pkg/util"
+)
+
+// scaledObjectValidator rejects ScaledObjects with invalid replica counts, scaleTargetRef, triggers or scalingModifiers,
+// and ScaledObjects targeting a workload that is already scaled by another ScaledObject or by an HPA not managed by KEDA
+type scaledObjectValidator struct {
+	client       client.Client
+	restMapper   meta.RESTMapper
+	scaleHandler scaling.ScaleHandler
+	decoder      *admission.Decoder
+	logger       logr.Logger
+}
+
+// Handle validates the ScaledObject in the admission request
+func (v *scaledObjectValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	scaledObject := &kedav1alpha1.ScaledObject{}
+	if err := v.decoder.Decode(req, scaledObject); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := v.validate(ctx, scaledObject); err != nil {
+		v.logger.V(1).Info("Rejecting ScaledObject", "ScaledObject.Namespace", scaledObject.Namespace, "ScaledObject.Name", scaledObject.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (v *scaledObjectValidator) validate(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject) error {
+	if err := validateReplicaCounts(scaledObject); err != nil {
+		return err
+	}
+
+	if scaledObject.Spec.ScaleTargetRef == nil || scaledObject.Spec.ScaleTargetRef.Name == "" {
+		return fmt.Errorf("scaleTargetRef.name must be specified")
+	}
+
+	if err := modifiers.Validate(scaledObject); err != nil {
+		return fmt.Errorf("invalid scalingModifiers: %s", err)
+	}
+
+	gvkr, err := kedautil.ParseGVKR(v.restMapper, scaledObject.Spec.ScaleTargetRef.APIVersion, scaledObject.Spec.ScaleTargetRef.Kind)
+	if err != nil {
+		return fmt.Errorf("invalid scaleTargetRef: %s", err)
+	}
+
+	if err := v.validateNoConflictingScaledObject(ctx, scaledObject, gvkr); err != nil {
+		return err
+	}
+
+	if err := v.validateNoUnmanagedHPA(ctx, scaledObject, gvkr); err != nil {
+		return err
+	}
+
+	// environment of the scale target is needed to resolve the trigger metadata,
+	// so triggers can be validated only if the scale target already exists
+	target := &unstructured.Unstructured{}
+	target.SetGroupVersionKind(gvkr.GroupVersionKind())
+	if err := v.client.Get(ctx, client.ObjectKey{Namespace: scaledObject.Namespace, Name: scaledObject.Spec.ScaleTargetRef.Name}, target); err != nil {
+		if errors.IsNotFound(err) {
+			v.logger.V(1).Info("Scale target doesn't exist, skipping validation of triggers", "ScaledObject.Namespace", scaledObject.Namespace, "ScaledObject.Name", scaledObject.Name)
+			return nil
+		}
+		return fmt.Errorf("error getting scale target %s %s: %s", gvkr.GVKString(), scaledObject.Spec.ScaleTargetRef.Name, err)
+	}
+
+	scaledObject = scaledObject.DeepCopy()
+	scaledObject.Status.ScaleTargetGVKR = &gvkr
+	if err := v.scaleHandler.ValidateScalableObject(scaledObject); err != nil {
+		return fmt.Errorf("invalid triggers: %s", err)
+	}
+
+	return nil
+}
+
+// validateReplicaCounts checks that minReplicaCount is not greater than maxReplicaCount
+func validateReplicaCounts(scaledObject *kedav1alpha1.ScaledObject) error {
+	minReplicaCount := int32(0)
+	if scaledObject.Spec.MinReplicaCount != nil {
+		minReplicaCount = *scaledObject.Spec.MinReplicaCount
+	}
+	maxReplicaCount := int32(100)
+	if scaledObject.Spec.MaxReplicaCount != nil {
+		maxReplicaCount = *scaledObject.Spec.MaxReplicaCount
+	}
+
+	if minReplicaCount > maxReplicaCount {
+		return fmt.Errorf("minReplicaCount (%d) must not be greater than maxReplicaCount (%d)", minReplicaCount, maxReplicaCount)
+	}
+	return nil
+}
+
+// validateNoConflictingScaledObject checks that no other ScaledObject in the namespace targets the same workload
+func (v *scaledObjectValidator) validateNoConflictingScaledObject(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, gvkr kedav1alpha1.GroupVersionKindResource) error {
+	scaledObjects := &kedav1alpha1.ScaledObjectList{}
+	if err := v.client.List(ctx, scaledObjects, client.InNamespace(scaledObject.Namespace)); err != nil {
+		return fmt.Errorf("error listing ScaledObjects: %s", err)
+	}
+
+	for _, other := range scaledObjects.Items {
+		if other.Name == scaledObject.Name || other.Spec.ScaleTargetRef == nil {
+			continue
+		}
+		otherKind := other.Spec.ScaleTargetRef.Kind
+		if otherKind == "" {
+			otherKind = "Deployment"
+		}
+		otherGroup := "apps"
+		if other.Spec.ScaleTargetRef.APIVersion != "" {
+			otherGroup = getGroup(other.Spec.ScaleTargetRef.APIVersion)
+		}
+		if other.Spec.ScaleTargetRef.Name == scaledObject.Spec.ScaleTargetRef.Name && otherKind == gvkr.Kind && otherGroup == gvkr.Group {
+			return fmt.Errorf("the workload '%s' of type '%s' is already managed by the ScaledObject '%s'", scaledObject.Spec.ScaleTargetRef.Name, gvkr.GVKString(), other.Name)
+		}
+	}
+	return nil
+}
+
+// validateNoUnmanagedHPA checks that the workload isn't scaled by an HPA that is not owned by a ScaledObject
+func (v *scaledObjectValidator) validateNoUnmanagedHPA(ctx context.Context, scaledObject *kedav1alpha1.ScaledObject, gvkr kedav1alpha1.GroupVersionKindResource) error {
+	hpas := &autoscalingv2beta2.HorizontalPodAutoscalerList{}
+	if err := v.client.List(ctx, hpas, client.InNamespace(scaledObject.Namespace)); err != nil {
+		return fmt.Errorf("error listing HPAs: %s", err)
+	}
+
+	for _, hpa := range hpas.Items {
+		if owner := metav1.GetControllerOf(&hpa); owner != nil && owner.Kind == "ScaledObject" && getGroup(owner.APIVersion) == kedav1alpha1.GroupVersion.Group {
+			continue
+		}
+		targetRef := hpa.Spec.ScaleTargetRef
+		if targetRef.Name == scaledObject.Spec.ScaleTargetRef.Name && targetRef.Kind == gvkr.Kind && getGroup(targetRef.APIVersion) == gvkr.Group {
+			return fmt.Errorf("the workload '%s' of type '%s' is already managed by the HPA '%s'", scaledObject.Spec.ScaleTargetRef.Name, gvkr.GVKString(), hpa.Name)
+		}
+	}
+	return nil
+}
+
+// getGroup returns the API group of the apiVersion, invalid apiVersion results in an empty group
+func getGroup(apiVersion string) string {
+	groupVersion, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return ""
+	}
+	return groupVersion.Group
+}
@@ -0,0 +1,146 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+	"github.com/kedacore/keda/pkg/scaling"
+)
+
+type fakeScaleHandler struct {
+	scaling.ScaleHandler
+	err error
+}
+
+func (h *fakeScaleHandler) ValidateScalableObject(scalableObject interface{}) error { return h.err }
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, kedav1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func newTestScaledObject(name, targetName string, minReplicaCount, maxReplicaCount int32) *kedav1alpha1.ScaledObject {
+	return &kedav1alpha1.ScaledObject{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kedav1alpha1.GroupVersion.String(), Kind: "ScaledObject"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef:  &kedav1alpha1.ScaleTarget{Name: targetName},
+			MinReplicaCount: &minReplicaCount,
+			MaxReplicaCount: &maxReplicaCount,
+			Triggers:        []kedav1alpha1.ScaleTriggers{{Type: "cron", Metadata: map[string]string{}}},
+		},
+	}
+}
+
+func newTestScaledObjectValidator(t *testing.T, validationErr error, objects ...runtime.Object) *scaledObjectValidator {
+	scheme := newTestScheme(t)
+	decoder, err := admission.NewDecoder(scheme)
+	assert.NoError(t, err)
+	return &scaledObjectValidator{
+		client:       fake.NewFakeClientWithScheme(scheme, objects...),
+		scaleHandler: &fakeScaleHandler{err: validationErr},
+		decoder:      decoder,
+		logger:       logf.Log.WithName("test"),
+	}
+}
+
+func newTestDeployment(name string) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"}}
+}
+
+func TestScaledObjectValidation(t *testing.T) {
+	ctx := context.Background()
+
+	v := newTestScaledObjectValidator(t, nil, newTestDeployment("deployment"))
+	assert.NoError(t, v.validate(ctx, newTestScaledObject("so", "deployment", 1, 10)))
+
+	err := v.validate(ctx, newTestScaledObject("so", "deployment", 10, 1))
+	assert.EqualError(t, err, "minReplicaCount (10) must not be greater than maxReplicaCount (1)")
+
+	err = v.validate(ctx, newTestScaledObject("so", "", 1, 10))
+	assert.EqualError(t, err, "scaleTargetRef.name must be specified")
+}
+
+func TestScaledObjectValidationOfTriggers(t *testing.T) {
+	ctx := context.Background()
+
+	v := newTestScaledObjectValidator(t, errors.New("no scaler found for type: foo"), newTestDeployment("deployment"))
+	err := v.validate(ctx, newTestScaledObject("so", "deployment", 1, 10))
+	assert.EqualError(t, err, "invalid triggers: no scaler found for type: foo")
+
+	// triggers are not validated if the scale target doesn't exist
+	v = newTestScaledObjectValidator(t, errors.New("no scaler found for type: foo"))
+	assert.NoError(t, v.validate(ctx, newTestScaledObject("so", "deployment", 1, 10)))
+}
+
+func TestScaledObjectValidationOfConflictingScaledObject(t *testing.T) {
+	ctx := context.Background()
+
+	v := newTestScaledObjectValidator(t, nil, newTestDeployment("deployment"), newTestScaledObject("other", "deployment", 1, 10))
+	err := v.validate(ctx, newTestScaledObject("so", "deployment", 1, 10))
+	assert.EqualError(t, err, "the workload 'deployment' of type 'apps/v1.Deployment' is already managed by the ScaledObject 'other'")
+
+	// updates of the ScaledObject itself are not conflicting
+	assert.NoError(t, v.validate(ctx, newTestScaledObject("other", "deployment", 1, 10)))
+
+	assert.NoError(t, v.validate(ctx, newTestScaledObject("so", "another-deployment", 1, 10)))
+}
+
+func TestScaledObjectValidationOfUnmanagedHPA(t *testing.T) {
+	ctx := context.Background()
+	isController := true
+
+	unmanagedHPA := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "hpa", Namespace: "test"},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "deployment"},
+		},
+	}
+	managedHPA := unmanagedHPA.DeepCopy()
+	managedHPA.Name = "keda-hpa-so"
+	managedHPA.OwnerReferences = []metav1.OwnerReference{{APIVersion: kedav1alpha1.GroupVersion.String(), Kind: "ScaledObject", Name: "so", Controller: &isController}}
+
+	v := newTestScaledObjectValidator(t, nil, newTestDeployment("deployment"), unmanagedHPA)
+	err := v.validate(ctx, newTestScaledObject("so", "deployment", 1, 10))
+	assert.EqualError(t, err, "the workload 'deployment' of type 'apps/v1.Deployment' is already managed by the HPA 'hpa'")
+
+	v = newTestScaledObjectValidator(t, nil, newTestDeployment("deployment"), managedHPA)
+	assert.NoError(t, v.validate(ctx, newTestScaledObject("so", "deployment", 1, 10)))
+}
+
+func TestScaledObjectHandle(t *testing.T) {
+	v := newTestScaledObjectValidator(t, nil, newTestDeployment("deployment"))
+
+	for _, testData := range []struct {
+		scaledObject *kedav1alpha1.ScaledObject
+		allowed      bool
+	}{
+		{newTestScaledObject("so", "deployment", 1, 10), true},
+		{newTestScaledObject("so", "deployment", 10, 1), false},
+	} {
+		raw, err := json.Marshal(testData.scaledObject)
+		assert.NoError(t, err)
+
+		resp := v.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: admissionv1beta1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		}})
+		assert.Equal(t, testData.allowed, resp.Allowed)
+	}
+}
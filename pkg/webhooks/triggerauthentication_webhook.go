@@ -0,0 +1,134 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+// triggerAuthenticationValidator rejects TriggerAuthentications with unknown pod identity providers,
+// incomplete references or parameters that are specified more than once
+type triggerAuthenticationValidator struct {
+	decoder *admission.Decoder
+	logger  logr.Logger
+}
+
+// Handle validates the TriggerAuthentication in the admission request
+func (v *triggerAuthenticationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	triggerAuth := &kedav1alpha1.TriggerAuthentication{}
+	if err := v.decoder.Decode(req, triggerAuth); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := validateTriggerAuthenticationSpec(&triggerAuth.Spec); err != nil {
+		v.logger.V(1).Info("Rejecting TriggerAuthentication", "TriggerAuthentication.Namespace", triggerAuth.Namespace, "TriggerAuthentication.Name", triggerAuth.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+// clusterTriggerAuthenticationValidator rejects ClusterTriggerAuthentications with invalid spec or namespaceSelector
+type clusterTriggerAuthenticationValidator struct {
+	decoder *admission.Decoder
+	logger  logr.Logger
+}
+
+// Handle validates the ClusterTriggerAuthentication in the admission request
+func (v *clusterTriggerAuthenticationValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	clusterTriggerAuth := &kedav1alpha1.ClusterTriggerAuthentication{}
+	if err := v.decoder.Decode(req, clusterTriggerAuth); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if err := validateClusterTriggerAuthenticationSpec(&clusterTriggerAuth.Spec); err != nil {
+		v.logger.V(1).Info("Rejecting ClusterTriggerAuthentication", "ClusterTriggerAuthentication.Name", clusterTriggerAuth.Name, "reason", err.Error())
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func validateClusterTriggerAuthenticationSpec(spec *kedav1alpha1.ClusterTriggerAuthenticationSpec) error {
+	if spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.NamespaceSelector); err != nil {
+			return fmt.Errorf("invalid namespaceSelector: %s", err)
+		}
+	}
+	return validateTriggerAuthenticationSpec(&spec.TriggerAuthenticationSpec)
+}
+
+func validateTriggerAuthenticationSpec(spec *kedav1alpha1.TriggerAuthenticationSpec) error {
+	if spec.PodIdentity != nil {
+		switch spec.PodIdentity.Provider {
+		case kedav1alpha1.PodIdentityProviderNone, kedav1alpha1.PodIdentityProviderAzure, kedav1alpha1.PodIdentityProviderGCP,
+			kedav1alpha1.PodIdentityProviderSpiffe, kedav1alpha1.PodIdentityProviderAwsEKS, kedav1alpha1.PodIdentityProviderAwsKiam,
+			kedav1alpha1.PodIdentityProviderAwsWebIdentity:
+		default:
+			return fmt.Errorf("unknown podIdentity.provider: %s", spec.PodIdentity.Provider)
+		}
+	}
+
+	parameters := make(map[string]bool)
+	addParameter := func(source, parameter string) error {
+		if parameter == "" {
+			return fmt.Errorf("%s: parameter must be specified", source)
+		}
+		if parameters[parameter] {
+			return fmt.Errorf("%s: parameter '%s' is specified more than once", source, parameter)
+		}
+		parameters[parameter] = true
+		return nil
+	}
+
+	for i, ref := range spec.SecretTargetRef {
+		source := fmt.Sprintf("secretTargetRef[%d]", i)
+		if err := addParameter(source, ref.Parameter); err != nil {
+			return err
+		}
+		if ref.Name == "" || ref.Key == "" {
+			return fmt.Errorf("%s: name and key must be specified", source)
+		}
+	}
+
+	for i, env := range spec.Env {
+		source := fmt.Sprintf("env[%d]", i)
+		if err := addParameter(source, env.Parameter); err != nil {
+			return err
+		}
+		if env.Name == "" {
+			return fmt.Errorf("%s: name must be specified", source)
+		}
+	}
+
+	if vault := spec.HashiCorpVault; vault != nil {
+		if vault.Address == "" {
+			return fmt.Errorf("hashiCorpVault: address must be specified")
+		}
+		switch vault.Authentication {
+		case kedav1alpha1.VaultAuthenticationToken:
+			// token can be also provided by VAULT_TOKEN environment variable of KEDA
+		case kedav1alpha1.VaultAuthenticationKubernetes:
+			if vault.Role == "" || vault.Mount == "" || vault.Credential == nil || vault.Credential.ServiceAccount == "" {
+				return fmt.Errorf("hashiCorpVault: role, mount and credential.serviceAccount must be specified for kubernetes authentication")
+			}
+		default:
+			return fmt.Errorf("hashiCorpVault: unknown authentication: %s", vault.Authentication)
+		}
+		for i, secret := range vault.Secrets {
+			source := fmt.Sprintf("hashiCorpVault.secrets[%d]", i)
+			if err := addParameter(source, secret.Parameter); err != nil {
+				return err
+			}
+			if secret.Path == "" || secret.Key == "" {
+				return fmt.Errorf("%s: path and key must be specified", source)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kedav1alpha1 "github.com/kedacore/keda/api/v1alpha1"
+)
+
+type triggerAuthenticationTestData struct {
+	name    string
+	spec    kedav1alpha1.TriggerAuthenticationSpec
+	isError bool
+}
+
+var testTriggerAuthenticationSpecs = []triggerAuthenticationTestData{
+	{"empty", kedav1alpha1.TriggerAuthenticationSpec{}, false},
+	{"valid", kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: "secret", Key: "host"}},
+		Env:             []kedav1alpha1.AuthEnvironment{{Parameter: "password", Name: "PASSWORD"}},
+	}, false},
+	{"known pod identity", kedav1alpha1.TriggerAuthenticationSpec{PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: kedav1alpha1.PodIdentityProviderAwsEKS}}, false},
+	{"unknown pod identity", kedav1alpha1.TriggerAuthenticationSpec{PodIdentity: &kedav1alpha1.AuthPodIdentity{Provider: "foo"}}, true},
+	{"missing secret key", kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: "secret"}},
+	}, true},
+	{"missing env parameter", kedav1alpha1.TriggerAuthenticationSpec{
+		Env: []kedav1alpha1.AuthEnvironment{{Name: "PASSWORD"}},
+	}, true},
+	{"duplicate parameter", kedav1alpha1.TriggerAuthenticationSpec{
+		SecretTargetRef: []kedav1alpha1.AuthSecretTargetRef{{Parameter: "host", Name: "secret", Key: "host"}},
+		Env:             []kedav1alpha1.AuthEnvironment{{Parameter: "host", Name: "HOST"}},
+	}, true},
+	{"vault token", kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+		Address:        "http://vault:8200",
+		Authentication: kedav1alpha1.VaultAuthenticationToken,
+		Secrets:        []kedav1alpha1.VaultSecret{{Parameter: "password", Path: "secret/data/keda", Key: "password"}},
+	}}, false},
+	{"vault kubernetes without role", kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+		Address:        "http://vault:8200",
+		Authentication: kedav1alpha1.VaultAuthenticationKubernetes,
+		Mount:          "kubernetes",
+		Credential:     &kedav1alpha1.Credential{ServiceAccount: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+	}}, true},
+	{"vault unknown authentication", kedav1alpha1.TriggerAuthenticationSpec{HashiCorpVault: &kedav1alpha1.HashiCorpVault{
+		Address:        "http://vault:8200",
+		Authentication: "foo",
+	}}, true},
+}
+
+func TestValidateTriggerAuthenticationSpec(t *testing.T) {
+	for _, testData := range testTriggerAuthenticationSpecs {
+		err := validateTriggerAuthenticationSpec(&testData.spec)
+		if err != nil && !testData.isError {
+			t.Errorf("Expected success for %s but got error: %s", testData.name, err)
+		}
+		if testData.isError && err == nil {
+			t.Errorf("Expected error for %s but got success", testData.name)
+		}
+	}
+}
+
+func TestValidateClusterTriggerAuthenticationSpec(t *testing.T) {
+	spec := &kedav1alpha1.ClusterTriggerAuthenticationSpec{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+	}
+	if err := validateClusterTriggerAuthenticationSpec(spec); err != nil {
+		t.Errorf("Expected success but got error: %s", err)
+	}
+
+	spec.NamespaceSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "foo"}}}
+	if err := validateClusterTriggerAuthenticationSpec(spec); err == nil {
+		t.Errorf("Expected error for invalid namespaceSelector but got success")
+	}
+
+	spec.NamespaceSelector = nil
+	spec.PodIdentity = &kedav1alpha1.AuthPodIdentity{Provider: "foo"}
+	if err := validateClusterTriggerAuthenticationSpec(spec); err == nil {
+		t.Errorf("Expected error for unknown pod identity but got success")
+	}
+}
@@ -0,0 +1,58 @@
+package webhooks
+
+import (
+	"fmt"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/kedacore/keda/pkg/scaling"
+)
+
+const (
+	scaledObjectValidationPath                 = "/validate-keda-sh-v1alpha1-scaledobject"
+	scaledJobValidationPath                    = "/validate-keda-sh-v1alpha1-scaledjob"
+	triggerAuthenticationValidationPath        = "/validate-keda-sh-v1alpha1-triggerauthentication"
+	clusterTriggerAuthenticationValidationPath = "/validate-keda-sh-v1alpha1-clustertriggerauthentication"
+)
+
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-scaledobject,mutating=false,failurePolicy=ignore,groups=keda.sh,resources=scaledobjects,verbs=create;update,versions=v1alpha1,name=vscaledobject.keda.sh
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-scaledjob,mutating=false,failurePolicy=ignore,groups=keda.sh,resources=scaledjobs,verbs=create;update,versions=v1alpha1,name=vscaledjob.keda.sh
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-triggerauthentication,mutating=false,failurePolicy=ignore,groups=keda.sh,resources=triggerauthentications,verbs=create;update,versions=v1alpha1,name=vtriggerauthentication.keda.sh
+// +kubebuilder:webhook:path=/validate-keda-sh-v1alpha1-clustertriggerauthentication,mutating=false,failurePolicy=ignore,groups=keda.sh,resources=clustertriggerauthentications,verbs=create;update,versions=v1alpha1,name=vclustertriggerauthentication.keda.sh
+
+// SetupWebhooks registers validating admission webhooks for ScaledObjects, ScaledJobs, TriggerAuthentications and ClusterTriggerAuthentications
+// to the webhook server of the manager
+func SetupWebhooks(mgr ctrl.Manager, scaleHandler scaling.ScaleHandler) error {
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("error creating admission decoder: %s", err)
+	}
+
+	logger := ctrl.Log.WithName("webhooks")
+	server := mgr.GetWebhookServer()
+
+	server.Register(scaledObjectValidationPath, &webhook.Admission{Handler: &scaledObjectValidator{
+		client:       mgr.GetClient(),
+		restMapper:   mgr.GetRESTMapper(),
+		scaleHandler: scaleHandler,
+		decoder:      decoder,
+		logger:       logger.WithName("ScaledObject"),
+	}})
+	server.Register(scaledJobValidationPath, &webhook.Admission{Handler: &scaledJobValidator{
+		scaleHandler: scaleHandler,
+		decoder:      decoder,
+		logger:       logger.WithName("ScaledJob"),
+	}})
+	server.Register(triggerAuthenticationValidationPath, &webhook.Admission{Handler: &triggerAuthenticationValidator{
+		decoder: decoder,
+		logger:  logger.WithName("TriggerAuthentication"),
+	}})
+	server.Register(clusterTriggerAuthenticationValidationPath, &webhook.Admission{Handler: &clusterTriggerAuthenticationValidator{
+		decoder: decoder,
+		logger:  logger.WithName("ClusterTriggerAuthentication"),
+	}})
+
+	return nil
+}
@@ -4,9 +4,9 @@ set -euo pipefail
 LEAD='TRIGGERS-START'
 TAIL='TRIGGERS-END'
 
-SCALERS_FILE="pkg/scaling/scale_handler.go"
-CURRENT=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep "case")
-SORTED=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep "case" | sort)
+SCALERS_FILE="pkg/scalers/registry.go"
+CURRENT=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep -o '^\s"[a-z-]*"')
+SORTED=$(cat "${SCALERS_FILE}" | awk "/${LEAD}/,/${TAIL}/" | grep -o '^\s"[a-z-]*"' | LC_ALL=C sort)
 
 if [[ "${CURRENT}" == "${SORTED}" ]]; then
   echo "Scalers are sorted in ${SCALERS_FILE}"